@@ -0,0 +1,137 @@
+// Package analytics summarizes a golfer's score history over time: rolling averages, trends, per course and per
+// season splits, aggregated round statistics and head to head comparisons between golfers.
+package analytics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+const day = 24 * time.Hour
+
+// Point is a value of a time series.
+type Point struct {
+	Date  time.Time
+	Value float64
+}
+
+// Trend is a straight line fitted through a time series.
+type Trend struct {
+	// Start is the date of the first point, where the line has the value Intercept.
+	Start     time.Time
+	Intercept float64
+	// SlopePerDay is the change of the value per day.
+	SlopePerDay float64
+}
+
+// Metric extracts the value of a score that is analyzed. The second value is false when the score has no value for
+// the metric, which leaves it out of the analysis.
+type Metric func(ghin.Score) (float64, bool)
+
+// AdjustedGrossScore is the Metric of the adjusted gross score of a round. Only 18 hole rounds have a value, since a
+// 9 hole score cannot be compared with them.
+func AdjustedGrossScore(s ghin.Score) (float64, bool) {
+	return float64(s.AdjustedGrossScore), s.NumberOfHoles != ghin.NineHolesPlayed
+}
+
+// Differential is the Metric of the score differential of a round.
+func Differential(s ghin.Score) (float64, bool) {
+	return s.Differential, true
+}
+
+// SortOldestFirst returns a copy of scores ordered from the first to the last played.
+func SortOldestFirst(scores []ghin.Score) []ghin.Score {
+	out := append([]ghin.Score(nil), scores...)
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].PlayedAt != out[j].PlayedAt {
+			return out[i].PlayedAt < out[j].PlayedAt
+		}
+		return out[i].ScoreDayOrder < out[j].ScoreDayOrder
+	})
+	return out
+}
+
+// Series returns the value of the metric for each score that has one in the order they were played.
+func Series(scores []ghin.Score, metric Metric) ([]Point, error) {
+	var out []Point
+	for _, s := range SortOldestFirst(scores) {
+		value, ok := metric(s)
+		if !ok {
+			continue
+		}
+		date, err := ghin.ParsePlayedAt(s.PlayedAt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem parsing played date of score %d", s.Id)
+		}
+		out = append(out, Point{Date: date, Value: value})
+	}
+	return out, nil
+}
+
+// RollingAverage returns the average of the metric over the last window rounds that have a value for it as of each of
+// them, starting with the round that completes the first window.
+func RollingAverage(scores []ghin.Score, metric Metric, window int) ([]Point, error) {
+	if window <= 0 {
+		return nil, errors.Errorf("window must be positive, got %d", window)
+	}
+	series, err := Series(scores, metric)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []Point
+	var total float64
+	for i, p := range series {
+		total += p.Value
+		if i >= window {
+			total -= series[i-window].Value
+		}
+		if i >= window-1 {
+			out = append(out, Point{Date: p.Date, Value: total / float64(window)})
+		}
+	}
+	return out, nil
+}
+
+// LinearTrend fits a least squares line through the points. At least two points on different dates are required.
+func LinearTrend(points []Point) (*Trend, error) {
+	if len(points) < 2 {
+		return nil, errors.Errorf("at least 2 points are required for a trend, got %d", len(points))
+	}
+
+	start := points[0].Date
+	for _, p := range points {
+		if p.Date.Before(start) {
+			start = p.Date
+		}
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for _, p := range points {
+		x := float64(p.Date.Sub(start)) / float64(day)
+		sumX += x
+		sumY += p.Value
+		sumXY += x * p.Value
+		sumXX += x * x
+	}
+	n := float64(len(points))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return nil, errors.New("points must span more than one date for a trend")
+	}
+
+	slope := (n*sumXY - sumX*sumY) / denominator
+	return &Trend{
+		Start:       start,
+		Intercept:   (sumY - slope*sumX) / n,
+		SlopePerDay: slope,
+	}, nil
+}
+
+// At returns the value of the trend line on a date.
+func (t Trend) At(date time.Time) float64 {
+	return t.Intercept + t.SlopePerDay*float64(date.Sub(t.Start))/float64(day)
+}
@@ -0,0 +1,194 @@
+package analytics
+
+import (
+	"github.com/C-Deck/ghin"
+	"github.com/C-Deck/ghin/handicap"
+	"github.com/pkg/errors"
+)
+
+// minimumScores is the number of scores required to calculate a Handicap Index.
+const minimumScores = 3
+
+// GolferHistory is the score history of a golfer taking part in a comparison.
+type GolferHistory struct {
+	GolferID string
+	Name     string
+	Scores   []ghin.Score
+}
+
+// GolferSummary describes one golfer of a comparison.
+type GolferSummary struct {
+	GolferID string
+	Name     string
+	Rounds   int
+	// Trajectory is the Handicap Index calculated from the scoring record as of each round, starting with the third
+	// round. Exceptional score reductions recorded on the scores are applied but caps are not.
+	Trajectory          []Point
+	AverageDifferential float64
+	// Statistics are the statistics of the rounds that reported them, see AggregateStatistics.
+	Statistics *ghin.RoundStatistics
+}
+
+// SharedCourse is a course played by several golfers of a comparison.
+type SharedCourse struct {
+	CourseID   string
+	CourseName string
+	// Splits summarize the rounds each golfer played on the course, in the order of the golfers compared.
+	Splits []Split
+}
+
+// Matchup compares two golfers of a comparison. Differences are the first golfer minus the second, so negative
+// differentials and scores favor the first golfer.
+type Matchup struct {
+	First  string
+	Second string
+	// SharedCourses are the courses both golfers played, in the order the first golfer played them.
+	SharedCourses []SharedCourse
+	// FirstSharedAverageDifferential and SecondSharedAverageDifferential are the average differentials of each golfer
+	// over the rounds played on the shared courses. They are zero when the golfers have no course in common.
+	FirstSharedAverageDifferential  float64
+	SecondSharedAverageDifferential float64
+	SharedDifferentialDifference    float64
+	// StatisticsDifference is nil unless both golfers have rounds with statistics.
+	StatisticsDifference *ghin.RoundStatistics
+}
+
+// HeadToHead compares the score histories of several golfers.
+type HeadToHead struct {
+	Golfers []GolferSummary
+	// CommonCourses are the courses played by every golfer.
+	CommonCourses []SharedCourse
+	// Matchups compare every pair of golfers, in the order they were given.
+	Matchups []Matchup
+}
+
+// Compare builds a head to head comparison of the score histories of at least two golfers.
+func Compare(golfers []GolferHistory) (*HeadToHead, error) {
+	if len(golfers) < 2 {
+		return nil, errors.Errorf("at least 2 golfers are required for a comparison, got %d", len(golfers))
+	}
+
+	out := &HeadToHead{}
+	for _, g := range golfers {
+		trajectory, err := IndexTrajectory(g.Scores)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem calculating index trajectory of golfer %s", g.GolferID)
+		}
+		out.Golfers = append(out.Golfers, GolferSummary{
+			GolferID:            g.GolferID,
+			Name:                g.Name,
+			Rounds:              len(g.Scores),
+			Trajectory:          trajectory,
+			AverageDifferential: averageDifferential(g.Scores),
+			Statistics:          AggregateStatistics(g.Scores),
+		})
+	}
+
+	out.CommonCourses = sharedCourses(golfers)
+	for i := range golfers {
+		for j := i + 1; j < len(golfers); j++ {
+			out.Matchups = append(out.Matchups, matchup(golfers[i], golfers[j], out.Golfers[i], out.Golfers[j]))
+		}
+	}
+	return out, nil
+}
+
+// IndexTrajectory calculates the Handicap Index from the scoring record as of each round of scores, in the order
+// they were played. Rounds before the third do not produce an index.
+func IndexTrajectory(scores []ghin.Score) ([]Point, error) {
+	scores = SortOldestFirst(scores)
+	var out []Point
+	for i, s := range scores {
+		record := make([]ghin.Score, 0, i+1)
+		for j := i; j >= 0 && len(record) < handicap.ScoringRecordSize; j-- {
+			record = append(record, scores[j])
+		}
+		if len(record) < minimumScores {
+			continue
+		}
+		index, err := handicap.Index(handicap.AdjustedDifferentials(record))
+		if err != nil {
+			return nil, err
+		}
+
+		date, err := ghin.ParsePlayedAt(s.PlayedAt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem parsing played date of score %d", s.Id)
+		}
+		out = append(out, Point{Date: date, Value: index})
+	}
+	return out, nil
+}
+
+func matchup(first, second GolferHistory, firstSummary, secondSummary GolferSummary) Matchup {
+	out := Matchup{
+		First:         first.GolferID,
+		Second:        second.GolferID,
+		SharedCourses: sharedCourses([]GolferHistory{first, second}),
+	}
+
+	if len(out.SharedCourses) > 0 {
+		shared := map[string]bool{}
+		for _, c := range out.SharedCourses {
+			shared[c.CourseID] = true
+		}
+		onShared := func(scores []ghin.Score) []ghin.Score {
+			var out []ghin.Score
+			for _, s := range scores {
+				if shared[s.CourseId] {
+					out = append(out, s)
+				}
+			}
+			return out
+		}
+		out.FirstSharedAverageDifferential = averageDifferential(onShared(first.Scores))
+		out.SecondSharedAverageDifferential = averageDifferential(onShared(second.Scores))
+		out.SharedDifferentialDifference = roundHundredth(out.FirstSharedAverageDifferential -
+			out.SecondSharedAverageDifferential)
+	}
+
+	if firstSummary.Statistics != nil && secondSummary.Statistics != nil {
+		out.StatisticsDifference = statisticsDifference(firstSummary.Statistics, secondSummary.Statistics)
+	}
+
+	return out
+}
+
+// sharedCourses returns the courses every golfer played, in the order the first golfer played them.
+func sharedCourses(golfers []GolferHistory) []SharedCourse {
+	splits := make([]map[string]Split, len(golfers))
+	for i, g := range golfers {
+		splits[i] = map[string]Split{}
+		for _, split := range ByCourse(SortOldestFirst(g.Scores)) {
+			splits[i][split.Key] = split
+		}
+	}
+
+	var out []SharedCourse
+	for _, split := range ByCourse(SortOldestFirst(golfers[0].Scores)) {
+		course := SharedCourse{CourseID: split.Key, CourseName: split.Name}
+		for i := range golfers {
+			s, ok := splits[i][split.Key]
+			if !ok {
+				break
+			}
+			course.Splits = append(course.Splits, s)
+		}
+		if len(course.Splits) == len(golfers) {
+			out = append(out, course)
+		}
+	}
+	return out
+}
+
+// averageDifferential returns the average differential of scores, or zero when there are none.
+func averageDifferential(scores []ghin.Score) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+	var total float64
+	for _, s := range scores {
+		total += s.Differential
+	}
+	return roundHundredth(total / float64(len(scores)))
+}
@@ -0,0 +1,163 @@
+package analytics
+
+import (
+	"sort"
+
+	"github.com/C-Deck/ghin"
+)
+
+// ScoreDistribution counts hole scores by their result relative to par.
+type ScoreDistribution struct {
+	EaglesOrBetter      int
+	Birdies             int
+	Pars                int
+	Bogeys              int
+	DoubleBogeys        int
+	TripleBogeysOrWorse int
+}
+
+// HoleProfile summarizes how a golfer scores on one hole of a tee set.
+type HoleProfile struct {
+	Number int
+	Par    int
+	// Allocation is the stroke index of the hole from the tee set, 1 being the hardest hole.
+	Allocation int
+	Rounds     int
+
+	AverageScore float64
+	AverageToPar float64
+	Distribution ScoreDistribution
+
+	// GreenInRegulationRate and FairwayHitRate are percentages of the rounds that recorded them.
+	GreenInRegulationRate float64
+	FairwayHitRate        float64
+
+	// DifficultyRank ranks the hole by AverageToPar for the golfer, 1 being the hole that costs the most strokes.
+	DifficultyRank int
+	// AllocationDifference is Allocation minus DifficultyRank. Positive values are holes that play harder for the
+	// golfer than their stroke index suggests.
+	AllocationDifference int
+}
+
+// CourseProfile is the per hole scoring profile of a golfer on a tee set.
+type CourseProfile struct {
+	CourseID string
+	TeeSetID string
+	Rounds   int
+	Holes    []HoleProfile
+}
+
+type holeTotals struct {
+	rounds, strokes, toPar int
+	greens, greensHit      int
+	fairways, fairwaysHit  int
+	par                    int
+	distribution           ScoreDistribution
+}
+
+// ProfileCourse aggregates the hole scores of every round played on the tee set of the course. Pars and stroke
+// allocations are taken from tee when it is provided, otherwise pars come from the hole scores and allocations are
+// left at zero.
+func ProfileCourse(scores []ghin.Score, courseID, teeSetID string, tee *ghin.TeeSetDetails) *CourseProfile {
+	out := &CourseProfile{CourseID: courseID, TeeSetID: teeSetID}
+	totals := map[int]*holeTotals{}
+	for _, s := range scores {
+		if s.CourseId != courseID || s.TeeSetId != teeSetID {
+			continue
+		}
+		out.Rounds++
+		for _, h := range s.HoleDetails {
+			t, ok := totals[h.HoleNumber]
+			if !ok {
+				t = &holeTotals{par: h.Par}
+				if tee != nil {
+					if details := tee.Hole(h.HoleNumber); details != nil {
+						t.par = details.Par
+					}
+				}
+				totals[h.HoleNumber] = t
+			}
+			t.add(h)
+		}
+	}
+
+	for number, t := range totals {
+		hole := HoleProfile{
+			Number:                number,
+			Par:                   t.par,
+			Rounds:                t.rounds,
+			AverageScore:          roundHundredth(float64(t.strokes) / float64(t.rounds)),
+			AverageToPar:          roundHundredth(float64(t.toPar) / float64(t.rounds)),
+			Distribution:          t.distribution,
+			GreenInRegulationRate: rate(t.greensHit, t.greens),
+			FairwayHitRate:        rate(t.fairwaysHit, t.fairways),
+		}
+		if tee != nil {
+			if details := tee.Hole(number); details != nil {
+				hole.Allocation = details.Allocation
+			}
+		}
+		out.Holes = append(out.Holes, hole)
+	}
+
+	sort.SliceStable(out.Holes, func(i, j int) bool {
+		if out.Holes[i].AverageToPar != out.Holes[j].AverageToPar {
+			return out.Holes[i].AverageToPar > out.Holes[j].AverageToPar
+		}
+		return out.Holes[i].Number < out.Holes[j].Number
+	})
+	for i := range out.Holes {
+		out.Holes[i].DifficultyRank = i + 1
+		if out.Holes[i].Allocation > 0 {
+			out.Holes[i].AllocationDifference = out.Holes[i].Allocation - out.Holes[i].DifficultyRank
+		}
+	}
+	sort.Slice(out.Holes, func(i, j int) bool {
+		return out.Holes[i].Number < out.Holes[j].Number
+	})
+
+	return out
+}
+
+func (t *holeTotals) add(h ghin.HoleScore) {
+	t.rounds++
+	t.strokes += h.RawScore
+	if t.par > 0 {
+		toPar := h.RawScore - t.par
+		t.toPar += toPar
+		switch {
+		case toPar <= -2:
+			t.distribution.EaglesOrBetter++
+		case toPar == -1:
+			t.distribution.Birdies++
+		case toPar == 0:
+			t.distribution.Pars++
+		case toPar == 1:
+			t.distribution.Bogeys++
+		case toPar == 2:
+			t.distribution.DoubleBogeys++
+		default:
+			t.distribution.TripleBogeysOrWorse++
+		}
+	}
+	if h.GreenInRegulation != nil {
+		t.greens++
+		if *h.GreenInRegulation {
+			t.greensHit++
+		}
+	}
+	if h.FairwayHit != nil {
+		t.fairways++
+		if *h.FairwayHit {
+			t.fairwaysHit++
+		}
+	}
+}
+
+// rate returns count as a percentage of total, or zero when total is zero.
+func rate(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return roundHundredth(float64(count) * 100 / float64(total))
+}
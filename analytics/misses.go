@@ -0,0 +1,193 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/C-Deck/ghin"
+)
+
+// ShotCategory is the kind of shot a miss is recorded for.
+type ShotCategory string
+
+const (
+	// ShotCategoryDriving covers tee shots, recorded by FairwayHit and DriveAccuracy.
+	ShotCategoryDriving ShotCategory = "Driving"
+	// ShotCategoryApproach covers shots into the green, recorded by GreenInRegulation and ApproachShotAccuracy.
+	ShotCategoryApproach ShotCategory = "Approach"
+)
+
+// missDirections are the directions a shot can miss in, in the order they are reported.
+var missDirections = []ghin.ShotAccuracy{
+	ghin.ShotAccuracyMissedLeft,
+	ghin.ShotAccuracyMissedRight,
+	ghin.ShotAccuracyMissedLong,
+	ghin.ShotAccuracyMissedShort,
+}
+
+// MissTally counts the outcomes of the shots of a category.
+type MissTally struct {
+	Attempts int
+	Hits     int
+	// Misses counts the misses that recorded their direction.
+	Misses map[ghin.ShotAccuracy]int
+	// Unspecified counts the misses that did not record their direction.
+	Unspecified int
+}
+
+// MissRate returns the percentage of attempts that missed in the direction.
+func (t MissTally) MissRate(direction ghin.ShotAccuracy) float64 {
+	return rate(t.Misses[direction], t.Attempts)
+}
+
+// MissReport tallies the misses of a set of rounds overall, by par of the hole and by hole number.
+type MissReport struct {
+	Rounds   int
+	Driving  MissTally
+	Approach MissTally
+
+	DrivingByPar   map[int]MissTally
+	ApproachByPar  map[int]MissTally
+	DrivingByHole  map[int]MissTally
+	ApproachByHole map[int]MissTally
+}
+
+// Tally returns the overall tally of a category.
+func (r MissReport) Tally(category ShotCategory) MissTally {
+	if category == ShotCategoryDriving {
+		return r.Driving
+	}
+	return r.Approach
+}
+
+// AnalyzeMisses tallies the drives and approach shots recorded in the hole scores. Holes without a par are only
+// counted in the overall and by hole tallies.
+func AnalyzeMisses(scores []ghin.Score) MissReport {
+	out := MissReport{
+		Driving:        newMissTally(),
+		Approach:       newMissTally(),
+		DrivingByPar:   map[int]MissTally{},
+		ApproachByPar:  map[int]MissTally{},
+		DrivingByHole:  map[int]MissTally{},
+		ApproachByHole: map[int]MissTally{},
+	}
+	for _, s := range scores {
+		out.Rounds++
+		for _, h := range s.HoleDetails {
+			if h.FairwayHit != nil {
+				record(&out.Driving, *h.FairwayHit, h.DriveAccuracy)
+				recordIn(out.DrivingByHole, h.HoleNumber, *h.FairwayHit, h.DriveAccuracy)
+				if h.Par > 0 {
+					recordIn(out.DrivingByPar, h.Par, *h.FairwayHit, h.DriveAccuracy)
+				}
+			}
+			if h.GreenInRegulation != nil {
+				record(&out.Approach, *h.GreenInRegulation, h.ApproachShotAccuracy)
+				recordIn(out.ApproachByHole, h.HoleNumber, *h.GreenInRegulation, h.ApproachShotAccuracy)
+				if h.Par > 0 {
+					recordIn(out.ApproachByPar, h.Par, *h.GreenInRegulation, h.ApproachShotAccuracy)
+				}
+			}
+		}
+	}
+	return out
+}
+
+// Tendency is a change in how often shots miss in a direction between two periods.
+type Tendency struct {
+	Category  ShotCategory
+	Direction ghin.ShotAccuracy
+	// From and To are the keys of the periods compared, formatted as 2006-01.
+	From string
+	To   string
+	// FromRate and ToRate are the percentages of attempts that missed in the direction in each period.
+	FromRate float64
+	ToRate   float64
+	// Change is the relative change from FromRate to ToRate as a percentage.
+	Change float64
+}
+
+func (t Tendency) String() string {
+	verb := "increased"
+	if t.Change < 0 {
+		verb = "decreased"
+	}
+	return fmt.Sprintf("%s misses %s %s %.0f%% in %s compared to %s", t.Category, t.Direction, verb,
+		math.Abs(t.Change), t.To, t.From)
+}
+
+// DetectTendencies compares the miss rates of the most recent month with the month before it and reports the
+// directions whose rate changed by at least threshold percent. A direction that was never missed in the earlier
+// month is reported when it is missed in the later one.
+func DetectTendencies(scores []ghin.Score, threshold float64) []Tendency {
+	months := map[string][]ghin.Score{}
+	var keys []string
+	for _, s := range SortOldestFirst(scores) {
+		month := monthKey(s)
+		if _, ok := months[month]; !ok {
+			keys = append(keys, month)
+		}
+		months[month] = append(months[month], s)
+	}
+	if len(keys) < 2 {
+		return nil
+	}
+
+	from, to := keys[len(keys)-2], keys[len(keys)-1]
+	before, after := AnalyzeMisses(months[from]), AnalyzeMisses(months[to])
+
+	var out []Tendency
+	for _, category := range []ShotCategory{ShotCategoryDriving, ShotCategoryApproach} {
+		for _, direction := range missDirections {
+			fromRate := before.Tally(category).MissRate(direction)
+			toRate := after.Tally(category).MissRate(direction)
+			var change float64
+			switch {
+			case fromRate == 0 && toRate == 0:
+				continue
+			case fromRate == 0:
+				change = 100
+			default:
+				change = roundHundredth((toRate - fromRate) / fromRate * 100)
+			}
+			if math.Abs(change) < threshold {
+				continue
+			}
+			out = append(out, Tendency{
+				Category:  category,
+				Direction: direction,
+				From:      from,
+				To:        to,
+				FromRate:  fromRate,
+				ToRate:    toRate,
+				Change:    change,
+			})
+		}
+	}
+	return out
+}
+
+func newMissTally() MissTally {
+	return MissTally{Misses: map[ghin.ShotAccuracy]int{}}
+}
+
+func record(t *MissTally, hit bool, direction *ghin.ShotAccuracy) {
+	t.Attempts++
+	switch {
+	case hit:
+		t.Hits++
+	case direction != nil:
+		t.Misses[*direction]++
+	default:
+		t.Unspecified++
+	}
+}
+
+func recordIn(tallies map[int]MissTally, key int, hit bool, direction *ghin.ShotAccuracy) {
+	t, ok := tallies[key]
+	if !ok {
+		t = newMissTally()
+	}
+	record(&t, hit, direction)
+	tallies[key] = t
+}
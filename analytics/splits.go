@@ -0,0 +1,152 @@
+package analytics
+
+import (
+	"math"
+
+	"github.com/C-Deck/ghin"
+)
+
+// Split summarizes the rounds that share a course, tee set, season or period.
+type Split struct {
+	// Key identifies the group of rounds, such as the course ID or the first day of the season.
+	Key string
+	// Name describes the group of rounds, such as the course name.
+	Name   string
+	Rounds int
+
+	// AverageAdjustedGrossScore and BestAdjustedGrossScore only count 18 hole rounds, see AdjustedGrossScore. They
+	// are zero when the split has none.
+	AverageAdjustedGrossScore float64
+	BestAdjustedGrossScore    int
+	AverageDifferential       float64
+	BestDifferential          float64
+
+	// Statistics are the statistics of the rounds that reported them, see AggregateStatistics.
+	Statistics *ghin.RoundStatistics
+}
+
+// SplitBy groups scores by the key returned for each of them and summarizes each group. Splits are returned in the
+// order their key first appears in scores. The name of a split is the name returned for its first score.
+func SplitBy(scores []ghin.Score, key func(ghin.Score) (string, string)) []Split {
+	var keys []string
+	groups := map[string][]ghin.Score{}
+	names := map[string]string{}
+	for _, s := range scores {
+		k, name := key(s)
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+			names[k] = name
+		}
+		groups[k] = append(groups[k], s)
+	}
+
+	out := make([]Split, len(keys))
+	for i, k := range keys {
+		out[i] = summarize(k, names[k], groups[k])
+	}
+	return out
+}
+
+// ByCourse summarizes the rounds played on each course.
+func ByCourse(scores []ghin.Score) []Split {
+	return SplitBy(scores, func(s ghin.Score) (string, string) {
+		return s.CourseId, s.CourseName
+	})
+}
+
+// ByTee summarizes the rounds played on each tee set. Split keys are the course and tee set IDs joined by a slash.
+func ByTee(scores []ghin.Score) []Split {
+	return SplitBy(scores, func(s ghin.Score) (string, string) {
+		name := s.CourseName
+		if s.TeeName != nil {
+			name += " - " + *s.TeeName
+		}
+		return s.CourseId + "/" + s.TeeSetId, name
+	})
+}
+
+// BySeason summarizes the rounds played in each season, using the season of the course when the round was played.
+// Split keys are the season start and end dates joined by a slash.
+func BySeason(scores []ghin.Score) []Split {
+	return SplitBy(SortOldestFirst(scores), func(s ghin.Score) (string, string) {
+		key := s.SeasonStartDateAt + "/" + s.SeasonEndDateAt
+		return key, key
+	})
+}
+
+// ByMonth summarizes the rounds played in each month. Split keys are formatted as 2006-01.
+func ByMonth(scores []ghin.Score) []Split {
+	return SplitBy(SortOldestFirst(scores), func(s ghin.Score) (string, string) {
+		month := monthKey(s)
+		return month, month
+	})
+}
+
+// monthKey returns the month a score was played formatted as 2006-01.
+func monthKey(s ghin.Score) string {
+	if len(s.PlayedAt) < len("2006-01") {
+		return s.PlayedAt
+	}
+	return s.PlayedAt[:len("2006-01")]
+}
+
+func summarize(key, name string, scores []ghin.Score) Split {
+	out := Split{
+		Key:              key,
+		Name:             name,
+		Rounds:           len(scores),
+		BestDifferential: math.Inf(1),
+		Statistics:       AggregateStatistics(scores),
+	}
+
+	var grossRounds, grossTotal int
+	var differentialTotal float64
+	for _, s := range scores {
+		differentialTotal += s.Differential
+		if s.Differential < out.BestDifferential {
+			out.BestDifferential = s.Differential
+		}
+		if _, ok := AdjustedGrossScore(s); !ok {
+			continue
+		}
+		grossRounds++
+		grossTotal += s.AdjustedGrossScore
+		if grossRounds == 1 || s.AdjustedGrossScore < out.BestAdjustedGrossScore {
+			out.BestAdjustedGrossScore = s.AdjustedGrossScore
+		}
+	}
+	if grossRounds > 0 {
+		out.AverageAdjustedGrossScore = roundHundredth(float64(grossTotal) / float64(grossRounds))
+	}
+	out.AverageDifferential = roundHundredth(differentialTotal / float64(len(scores)))
+
+	return out
+}
+
+func roundHundredth(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// Comparison is the change between two consecutive splits, such as one season to the next.
+type Comparison struct {
+	From Split
+	To   Split
+
+	AverageAdjustedGrossScoreChange float64
+	AverageDifferentialChange       float64
+}
+
+// CompareSplits compares each split with the one before it.
+func CompareSplits(splits []Split) []Comparison {
+	var out []Comparison
+	for i := 1; i < len(splits); i++ {
+		from, to := splits[i-1], splits[i]
+		out = append(out, Comparison{
+			From:                            from,
+			To:                              to,
+			AverageAdjustedGrossScoreChange: roundHundredth(to.AverageAdjustedGrossScore - from.AverageAdjustedGrossScore),
+			AverageDifferentialChange:       roundHundredth(to.AverageDifferential - from.AverageDifferential),
+		})
+	}
+	return out
+}
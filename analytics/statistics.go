@@ -0,0 +1,157 @@
+package analytics
+
+import (
+	"math"
+
+	"github.com/C-Deck/ghin"
+)
+
+// statisticAverage is the running average of a statistic over the rounds that reported it.
+type statisticAverage struct {
+	sum    float64
+	rounds int
+}
+
+func (a *statisticAverage) add(v float64) {
+	a.sum += v
+	a.rounds++
+}
+
+func (a statisticAverage) hundredth() float64 {
+	if a.rounds == 0 {
+		return 0
+	}
+	return roundHundredth(a.sum / float64(a.rounds))
+}
+
+func (a statisticAverage) whole() int {
+	if a.rounds == 0 {
+		return 0
+	}
+	return int(math.Round(a.sum / float64(a.rounds)))
+}
+
+// AggregateStatistics averages the statistics of the scores that reported them. PuttsTotal and UpAndDownsTotal become
+// the average per 18 holes, the totals of nine-hole rounds being doubled, and Par3SAverage, Par4SAverage and
+// Par5SAverage are only averaged over the rounds that played a hole of that par. Nil is returned when none of the
+// scores have statistics.
+func AggregateStatistics(scores []ghin.Score) *ghin.RoundStatistics {
+	var (
+		rounds                                                               int
+		gir, putts, onePutt, twoPutt, threePutt, twoPuttOrBetter, upAndDowns statisticAverage
+		pars, par3s, par4s, par5s, bogeys, doubleBogeys, birdies, triples    statisticAverage
+		missedLeft, missedLong, fairways, missedRight, missedShort           statisticAverage
+		approachLeft, approachRight, approachLong, approachShort, approach   statisticAverage
+	)
+	for _, s := range scores {
+		st := s.Statistics
+		if st == nil {
+			continue
+		}
+		rounds++
+		perEighteen := 1.0
+		if s.NumberOfHoles == ghin.NineHolesPlayed {
+			perEighteen = 2
+		}
+
+		gir.add(float64(st.GirPercent))
+		putts.add(float64(st.PuttsTotal) * perEighteen)
+		onePutt.add(st.OnePuttOrBetterPercent)
+		twoPutt.add(st.TwoPuttPercent)
+		threePutt.add(st.ThreePuttOrWorsePercent)
+		twoPuttOrBetter.add(st.TwoPuttOrBetterPercent)
+		upAndDowns.add(float64(st.UpAndDownsTotal) * perEighteen)
+		pars.add(st.ParsPercent)
+		if st.Par3SAverage != 0 {
+			par3s.add(st.Par3SAverage)
+		}
+		if st.Par4SAverage != 0 {
+			par4s.add(st.Par4SAverage)
+		}
+		if st.Par5SAverage != 0 {
+			par5s.add(st.Par5SAverage)
+		}
+		bogeys.add(st.BogeysPercent)
+		missedLeft.add(float64(st.MissedLeftPercent))
+		missedLong.add(float64(st.MissedLongPercent))
+		fairways.add(float64(st.FairwayHitsPercent))
+		missedRight.add(float64(st.MissedRightPercent))
+		missedShort.add(float64(st.MissedShortPercent))
+		doubleBogeys.add(st.DoubleBogeysPercent)
+		birdies.add(float64(st.BirdiesOrBetterPercent))
+		triples.add(st.TripleBogeysOrWorsePercent)
+		approachLeft.add(float64(st.MissedLeftApproachShotAccuracyPercent))
+		approachRight.add(float64(st.MissedRightApproachShotAccuracyPercent))
+		approachLong.add(float64(st.MissedLongApproachShotAccuracyPercent))
+		approachShort.add(float64(st.MissedShortApproachShotAccuracyPercent))
+		approach.add(float64(st.MissedGeneralApproachShotAccuracyPercent))
+	}
+	if rounds == 0 {
+		return nil
+	}
+
+	return &ghin.RoundStatistics{
+		GirPercent:                               gir.whole(),
+		PuttsTotal:                               putts.whole(),
+		OnePuttOrBetterPercent:                   onePutt.hundredth(),
+		TwoPuttPercent:                           twoPutt.hundredth(),
+		ThreePuttOrWorsePercent:                  threePutt.hundredth(),
+		TwoPuttOrBetterPercent:                   twoPuttOrBetter.hundredth(),
+		UpAndDownsTotal:                          upAndDowns.whole(),
+		ParsPercent:                              pars.hundredth(),
+		Par3SAverage:                             par3s.hundredth(),
+		Par4SAverage:                             par4s.hundredth(),
+		Par5SAverage:                             par5s.hundredth(),
+		BogeysPercent:                            bogeys.hundredth(),
+		MissedLeftPercent:                        missedLeft.whole(),
+		MissedLongPercent:                        missedLong.whole(),
+		FairwayHitsPercent:                       fairways.whole(),
+		MissedRightPercent:                       missedRight.whole(),
+		MissedShortPercent:                       missedShort.whole(),
+		DoubleBogeysPercent:                      doubleBogeys.hundredth(),
+		BirdiesOrBetterPercent:                   birdies.whole(),
+		TripleBogeysOrWorsePercent:               triples.hundredth(),
+		MissedLeftApproachShotAccuracyPercent:    approachLeft.whole(),
+		MissedRightApproachShotAccuracyPercent:   approachRight.whole(),
+		MissedLongApproachShotAccuracyPercent:    approachLong.whole(),
+		MissedShortApproachShotAccuracyPercent:   approachShort.whole(),
+		MissedGeneralApproachShotAccuracyPercent: approach.whole(),
+	}
+}
+
+// statisticsDifference subtracts the second statistics from the first, field by field.
+func statisticsDifference(first, second *ghin.RoundStatistics) *ghin.RoundStatistics {
+	return &ghin.RoundStatistics{
+		GirPercent:              first.GirPercent - second.GirPercent,
+		PuttsTotal:              first.PuttsTotal - second.PuttsTotal,
+		OnePuttOrBetterPercent:  roundHundredth(first.OnePuttOrBetterPercent - second.OnePuttOrBetterPercent),
+		TwoPuttPercent:          roundHundredth(first.TwoPuttPercent - second.TwoPuttPercent),
+		ThreePuttOrWorsePercent: roundHundredth(first.ThreePuttOrWorsePercent - second.ThreePuttOrWorsePercent),
+		TwoPuttOrBetterPercent:  roundHundredth(first.TwoPuttOrBetterPercent - second.TwoPuttOrBetterPercent),
+		UpAndDownsTotal:         first.UpAndDownsTotal - second.UpAndDownsTotal,
+		ParsPercent:             roundHundredth(first.ParsPercent - second.ParsPercent),
+		Par3SAverage:            roundHundredth(first.Par3SAverage - second.Par3SAverage),
+		Par4SAverage:            roundHundredth(first.Par4SAverage - second.Par4SAverage),
+		Par5SAverage:            roundHundredth(first.Par5SAverage - second.Par5SAverage),
+		BogeysPercent:           roundHundredth(first.BogeysPercent - second.BogeysPercent),
+		MissedLeftPercent:       first.MissedLeftPercent - second.MissedLeftPercent,
+		MissedLongPercent:       first.MissedLongPercent - second.MissedLongPercent,
+		FairwayHitsPercent:      first.FairwayHitsPercent - second.FairwayHitsPercent,
+		MissedRightPercent:      first.MissedRightPercent - second.MissedRightPercent,
+		MissedShortPercent:      first.MissedShortPercent - second.MissedShortPercent,
+		DoubleBogeysPercent:     roundHundredth(first.DoubleBogeysPercent - second.DoubleBogeysPercent),
+		BirdiesOrBetterPercent:  first.BirdiesOrBetterPercent - second.BirdiesOrBetterPercent,
+		TripleBogeysOrWorsePercent: roundHundredth(first.TripleBogeysOrWorsePercent -
+			second.TripleBogeysOrWorsePercent),
+		MissedLeftApproachShotAccuracyPercent: first.MissedLeftApproachShotAccuracyPercent -
+			second.MissedLeftApproachShotAccuracyPercent,
+		MissedRightApproachShotAccuracyPercent: first.MissedRightApproachShotAccuracyPercent -
+			second.MissedRightApproachShotAccuracyPercent,
+		MissedLongApproachShotAccuracyPercent: first.MissedLongApproachShotAccuracyPercent -
+			second.MissedLongApproachShotAccuracyPercent,
+		MissedShortApproachShotAccuracyPercent: first.MissedShortApproachShotAccuracyPercent -
+			second.MissedShortApproachShotAccuracyPercent,
+		MissedGeneralApproachShotAccuracyPercent: first.MissedGeneralApproachShotAccuracyPercent -
+			second.MissedGeneralApproachShotAccuracyPercent,
+	}
+}
@@ -3,6 +3,7 @@ package ghin
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/url"
 	"strconv"
 	"time"
@@ -25,6 +26,11 @@ func (c *Client) Login(ctx context.Context, email, password string) error {
 }
 
 type SubmitScoreInput struct {
+	/* GolferID is the GHIN number of the golfer the score is posted for.
+	 * Default: The logged in golfer
+	 */
+	GolferID *string `json:"golfer_id,omitempty"`
+
 	// Gender is the gender of the golfer.
 	Gender PlayerGender `json:"gender"`
 
@@ -56,14 +62,118 @@ type SubmitScoreInput struct {
 	 * Default: Away
 	 */
 	ScoreType *ScoringType `json:"score_type"`
+
+	/* AllowDuplicate posts the score even if the golfer already posted the same score, confirming the duplicate to GHIN.
+	 * Default: false, the score that was already posted is returned
+	 */
+	AllowDuplicate *bool `json:"allow_duplicate,omitempty"`
 }
 
-// SubmitScore submits a score for a golfer.
+// SubmitScore submits a score for a golfer. The golfer's recent scores are checked first so that retrying a
+// submission that GHIN already accepted returns the posted score instead of posting a duplicate.
 func (c *Client) SubmitScore(ctx context.Context, input SubmitScoreInput) (*Score, error) {
 	if c.user == nil {
 		return nil, NewUserNotLoggedInError("cannot submit score without user login")
 	}
 
+	submission := c.newScoreSubmission(input)
+	if err := ValidateSubmission(submission, nil); err != nil {
+		return nil, err
+	}
+
+	limit := duplicateSearchLimit
+	recent, err := c.GetUserInfo(ctx, GetUserInfoInput{GolferID: &submission.GolferID, Limit: &limit})
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem checking for a previously posted score")
+	}
+	if existing := FindDuplicateScore(recent.Scores, submission); existing != nil {
+		if input.AllowDuplicate == nil || !*input.AllowDuplicate {
+			return existing, nil
+		}
+		override := true
+		submission.OverrideConfirmation = &override
+	}
+
+	out, err := postAndDeserialize[Score, ScoreSubmission](c.client, ctx, postScorePath, submission)
+	if err != nil {
+		return nil, errors.Wrapf(err, "")
+	}
+
+	return out, nil
+}
+
+// PreviewScore builds the Score that SubmitScore would post without posting it. The submission is validated against
+// the course details, hole scores are adjusted with the golfer's maximum hole scores, and the differential is
+// calculated from the rating of the holes played. A 9 hole score has no differential until it is converted to 18
+// holes with the golfer's Handicap Index, which handicap.ScoreEighteenHoleDifferential does for the posted score.
+func (c *Client) PreviewScore(ctx context.Context, input SubmitScoreInput) (*Score, error) {
+	if c.user == nil {
+		return nil, NewUserNotLoggedInError("cannot preview score without user login")
+	}
+
+	submission := c.newScoreSubmission(input)
+	course, err := c.GetCourseDetails(ctx, GetCourseDetailsInput{CourseID: strconv.Itoa(submission.CourseID)})
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidateSubmission(submission, course); err != nil {
+		return nil, err
+	}
+	tee := course.TeeSet(submission.TeeSetID)
+	rating := tee.Rating(submission.TeeSetSide.RatingType())
+	if rating == nil {
+		return nil, errors.Errorf("tee set %d has no %s rating", submission.TeeSetID, submission.TeeSetSide.RatingType())
+	}
+
+	maxScores, err := c.GetMaxHoleScores(ctx, GetMaxHoleScoresInput{
+		GolferID:   &submission.GolferID,
+		CourseID:   submission.CourseID,
+		TeeSetID:   submission.TeeSetID,
+		TeeSetSide: &submission.TeeSetSide,
+		PlayedAt:   input.PlayedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	holes := make([]HoleScore, len(submission.HoleDetails))
+	for i, h := range submission.HoleDetails {
+		if details := tee.Hole(h.HoleNumber); details != nil && h.Par == 0 {
+			h.Par = details.Par
+		}
+		holes[i] = h
+	}
+
+	out := &Score{
+		Gender:              submission.Gender,
+		IsManual:            true,
+		NumberOfHoles:       submission.NumberOfHoles,
+		NumberOfPlayedHoles: HolesPlayed(len(holes)),
+		GolferId:            submission.GolferID,
+		CourseId:            strconv.Itoa(course.CourseId),
+		CourseName:          course.CourseName,
+		FacilityName:        &course.Facility.FacilityName,
+		PlayedAt:            submission.PlayedAt,
+		AdjustedGrossScore:  AdjustedGrossScore(holes, maxScores),
+		ScoreType:           submission.ScoreType,
+		TeeName:             &tee.TeeSetRatingName,
+		TeeSetId:            strconv.Itoa(submission.TeeSetID),
+		TeeSetSide:          submission.TeeSetSide,
+		CourseRating:        rating.CourseRating,
+		SlopeRating:         int(rating.SlopeRating),
+		HoleDetails:         holes,
+		Statistics:          ComputeRoundStatistics(holes, tee),
+	}
+	if out.NumberOfHoles == EighteenHolesPlayed {
+		out.Differential = ScoreDifferential(out.AdjustedGrossScore, out.CourseRating, out.SlopeRating, 0)
+		out.UnadjustedDifferential = out.Differential
+	}
+
+	return out, nil
+}
+
+// newScoreSubmission builds the ScoreSubmission for the golfer of the input, filling in the defaults of the input.
+func (c *Client) newScoreSubmission(input SubmitScoreInput) ScoreSubmission {
 	submission := ScoreSubmission{
 		GolferID:      strconv.Itoa(c.user.GolferId),
 		Gender:        input.Gender,
@@ -75,6 +185,9 @@ func (c *Client) SubmitScore(ctx context.Context, input SubmitScoreInput) (*Scor
 		TeeSetSide:    TeeSetSide18,
 		TeeSetID:      input.TeeSetID,
 	}
+	if input.GolferID != nil {
+		submission.GolferID = *input.GolferID
+	}
 	if input.TeeSetSide != nil {
 		submission.TeeSetSide = *input.TeeSetSide
 	}
@@ -87,23 +200,299 @@ func (c *Client) SubmitScore(ctx context.Context, input SubmitScoreInput) (*Scor
 	if input.PlayedAt != nil {
 		submission.PlayedAt = ToPlayedAtString(*input.PlayedAt)
 	}
-	out, err := postAndDeserialize[Score, ScoreSubmission](c.client, ctx, postScorePath, submission)
+	return submission
+}
+
+type SubmitTotalScoreInput struct {
+	// Gender is the gender of the golfer.
+	Gender PlayerGender `json:"gender"`
+
+	// CourseID is the ID of the course played.
+	CourseID int `json:"course_id"`
+
+	// TeeSetID is the ID of the tee box that was played (whites, blues, etc) for the course.
+	TeeSetID int `json:"tee_set_id"`
+
+	/* TeeSetSide represents the which holes were played. Front/Back 9 or that 18 holes.
+	 * Default: All18
+	 */
+	TeeSetSide *TeeSetSide `json:"tee_set_side"`
+
+	/* PlayedAt is the date the round was played.
+	 * Default: Current date
+	 */
+	PlayedAt *time.Time `json:"played_at"`
+
+	// AdjustedGrossScore is the total score of the round after any hole score adjustments.
+	AdjustedGrossScore int `json:"adjusted_gross_score"`
+
+	// FrontNineScore is the adjusted score of the front nine, if it was recorded.
+	FrontNineScore *int `json:"front9_adjusted"`
+
+	// BackNineScore is the adjusted score of the back nine, if it was recorded.
+	BackNineScore *int `json:"back9_adjusted"`
+
+	/* NumberOfHoles represents whether 9 or 18 holes were played.
+	 * Default: 18
+	 */
+	NumberOfHoles *HolesPlayed `json:"number_of_holes"`
+
+	/* ScoreType is the situation the round was played (Away, Home, or Tournament).
+	 * Default: Away
+	 */
+	ScoreType *ScoringType `json:"score_type"`
+}
+
+// SubmitTotalScore submits the total score of a round for a golfer, for rounds that were not scored hole by hole.
+func (c *Client) SubmitTotalScore(ctx context.Context, input SubmitTotalScoreInput) (*Score, error) {
+	if c.user == nil {
+		return nil, NewUserNotLoggedInError("cannot submit score without user login")
+	}
+
+	defaults := c.newScoreSubmission(SubmitScoreInput{
+		Gender:        input.Gender,
+		CourseID:      input.CourseID,
+		TeeSetID:      input.TeeSetID,
+		TeeSetSide:    input.TeeSetSide,
+		PlayedAt:      input.PlayedAt,
+		NumberOfHoles: input.NumberOfHoles,
+		ScoreType:     input.ScoreType,
+	})
+	submission := TotalScoreSubmission{
+		GolferID:           defaults.GolferID,
+		Gender:             defaults.Gender,
+		CourseID:           defaults.CourseID,
+		TeeSetID:           defaults.TeeSetID,
+		TeeSetSide:         defaults.TeeSetSide,
+		PlayedAt:           defaults.PlayedAt,
+		NumberOfHoles:      defaults.NumberOfHoles,
+		ScoreType:          defaults.ScoreType,
+		AdjustedGrossScore: input.AdjustedGrossScore,
+		FrontNineScore:     input.FrontNineScore,
+		BackNineScore:      input.BackNineScore,
+	}
+	if err := ValidateTotalSubmission(submission, nil); err != nil {
+		return nil, err
+	}
+	out, err := postAndDeserialize[Score, TotalScoreSubmission](c.client, ctx, postTotalScorePath, submission)
 	if err != nil {
-		return nil, errors.Wrapf(err, "")
+		return nil, errors.Wrapf(err, "problem submitting total score")
 	}
 
 	return out, nil
 }
 
+type UpdateScoreInput struct {
+	// CourseID is the ID of the course played, if it should be changed.
+	CourseID *int
+
+	// TeeSetID is the ID of the tee box that was played, if it should be changed.
+	TeeSetID *int
+
+	// TeeSetSide represents the which holes were played, if it should be changed.
+	TeeSetSide *TeeSetSide
+
+	// PlayedAt is the date the round was played, if it should be changed.
+	PlayedAt *time.Time
+
+	// HoleDetails replaces the scores and statistics for each hole when provided.
+	HoleDetails []HoleScore
+
+	// ScoreType is the situation the round was played (Away, Home, or Tournament), if it should be changed.
+	ScoreType *ScoringType
+}
+
+// UpdateScore corrects a posted score. Only the fields of the input that are provided are changed. The score is
+// retrieved again once it is corrected, so the returned score has the values GHIN recorded, such as Edited and
+// Revision.
+func (c *Client) UpdateScore(ctx context.Context, scoreID int, input UpdateScoreInput) (*Score, error) {
+	if c.user == nil {
+		return nil, NewUserNotLoggedInError("cannot update score without user login")
+	}
+
+	update := ScoreUpdate{
+		CourseID:    input.CourseID,
+		TeeSetID:    input.TeeSetID,
+		TeeSetSide:  input.TeeSetSide,
+		HoleDetails: input.HoleDetails,
+		ScoreType:   input.ScoreType,
+	}
+	if input.PlayedAt != nil {
+		playedAt := ToPlayedAtString(*input.PlayedAt)
+		update.PlayedAt = &playedAt
+	}
+	if err := ValidateScoreUpdate(update); err != nil {
+		return nil, err
+	}
+
+	body, err := json.Marshal(update)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem serializing data for put request")
+	}
+	if _, err := c.client.Put(ctx, fmt.Sprintf(updateScorePath, scoreID), body); err != nil {
+		return nil, errors.Wrapf(err, "problem updating score %d", scoreID)
+	}
+
+	out, err := getAndDeserialize[Score](c.client, ctx, fmt.Sprintf(scorePath, scoreID), nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem retrieving updated score %d", scoreID)
+	}
+
+	return out, nil
+}
+
+// DeleteScore removes a posted score.
+func (c *Client) DeleteScore(ctx context.Context, scoreID int) error {
+	if c.user == nil {
+		return NewUserNotLoggedInError("cannot delete score without user login")
+	}
+
+	_, err := c.client.Delete(ctx, fmt.Sprintf(scorePath, scoreID))
+	if err != nil {
+		return errors.Wrapf(err, "problem deleting score %d", scoreID)
+	}
+
+	return nil
+}
+
+type GetMaxHoleScoresInput struct {
+	/* GolferID is the GHIN number of the golfer whose maximum hole scores are retrieved.
+	 * Default: The logged in golfer
+	 */
+	GolferID *string
+
+	// CourseID is the ID of the course played.
+	CourseID int
+
+	// TeeSetID is the ID of the tee box that was played for the course.
+	TeeSetID int
+
+	/* TeeSetSide represents the which holes were played. Front/Back 9 or that 18 holes.
+	 * Default: All18
+	 */
+	TeeSetSide *TeeSetSide
+
+	/* PlayedAt is the date the round was played.
+	 * Default: Current date
+	 */
+	PlayedAt *time.Time
+}
+
+type maxHoleScoresResponse struct {
+	MaxHoleScores []MaxHoleScore `json:"maximum_hole_scores"`
+}
+
+// GetMaxHoleScores retrieves the highest score of each hole that counts towards the adjusted gross score of a golfer.
+func (c *Client) GetMaxHoleScores(ctx context.Context, input GetMaxHoleScoresInput) ([]MaxHoleScore, error) {
+	if c.user == nil {
+		return nil, NewUserNotLoggedInError("cannot retrieve maximum hole scores without user login")
+	}
+
+	params := url.Values{}
+	params.Set("golfer_id", strconv.Itoa(c.user.GolferId))
+	params.Set("course_id", strconv.Itoa(input.CourseID))
+	params.Set("tee_set_id", strconv.Itoa(input.TeeSetID))
+	params.Set("tee_set_side", string(TeeSetSide18))
+	params.Set("played_at", ToPlayedAtString(time.Now()))
+	if input.GolferID != nil {
+		params.Set("golfer_id", *input.GolferID)
+	}
+	if input.TeeSetSide != nil {
+		params.Set("tee_set_side", string(*input.TeeSetSide))
+	}
+	if input.PlayedAt != nil {
+		params.Set("played_at", ToPlayedAtString(*input.PlayedAt))
+	}
+
+	out, err := getAndDeserialize[maxHoleScoresResponse](c.client, ctx, maxScoresPath, params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem retrieving maximum hole scores for course %d", input.CourseID)
+	}
+
+	return out.MaxHoleScores, nil
+}
+
 type GetUserInfoInput struct {
-	Offset *int
-	Limit  *int
+	/* GolferID is the GHIN number of the golfer whose scores are retrieved.
+	 * Default: The logged in golfer
+	 */
+	GolferID *string
+	Offset   *int
+	Limit    *int
 	// Statuses are the status
 	Statuses *string
 }
 
+// GetUserInfo retrieves the posted scores of a golfer, most recent first.
 func (c *Client) GetUserInfo(ctx context.Context, input GetUserInfoInput) (*GolferScores, error) {
+	if c.user == nil {
+		return nil, NewUserNotLoggedInError("cannot retrieve scores without user login")
+	}
+
+	golferID := strconv.Itoa(c.user.GolferId)
+	if input.GolferID != nil {
+		golferID = *input.GolferID
+	}
+	params := url.Values{}
+	params.Set("golfer_id", golferID)
+	if input.Offset != nil {
+		params.Set("offset", strconv.Itoa(*input.Offset))
+	}
+	if input.Limit != nil {
+		params.Set("limit", strconv.Itoa(*input.Limit))
+	}
+	if input.Statuses != nil {
+		params.Set("statuses", *input.Statuses)
+	}
+
+	out, err := getAndDeserialize[GolferScores](c.client, ctx, scoresPath, params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem retrieving scores for golfer %s", golferID)
+	}
+
+	return out, nil
+}
+
+type golfersResponse struct {
+	Golfers []Golfer `json:"golfers"`
+}
+
+// GetGolfer looks up a golfer by GHIN number. A GolferNotFoundError is returned when no golfer has the number.
+func (c *Client) GetGolfer(ctx context.Context, ghinNumber string) (*Golfer, error) {
+	params := url.Values{}
+	params.Set("golfer_id", ghinNumber)
+	params.Set("page", "1")
+	params.Set("per_page", "1")
+
+	out, err := getAndDeserialize[golfersResponse](c.client, ctx, searchGolfersPath, params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem looking up golfer %q", ghinNumber)
+	}
+	if len(out.Golfers) == 0 || out.Golfers[0].GhinNumber != ghinNumber {
+		return nil, NewGolferNotFoundError(ghinNumber)
+	}
+
+	return &out.Golfers[0], nil
+}
+
+type handicapHistoryResponse struct {
+	Revisions []HandicapRevision `json:"handicap_revisions"`
+}
+
+// GetHandicapHistory retrieves the Handicap Index revisions of a golfer issued between from and to.
+func (c *Client) GetHandicapHistory(ctx context.Context, ghinNumber string, from, to time.Time) ([]HandicapRevision, error) {
+	params := url.Values{}
+	params.Set("date_begin", ToPlayedAtString(from))
+	params.Set("date_end", ToPlayedAtString(to))
+	params.Set("rev_count", "0")
+
+	path := fmt.Sprintf(handicapHistoryPath, url.PathEscape(ghinNumber))
+	out, err := getAndDeserialize[handicapHistoryResponse](c.client, ctx, path, params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem retrieving handicap history for golfer %q", ghinNumber)
+	}
 
+	return out.Revisions, nil
 }
 
 type GetCourseDetailsInput struct {
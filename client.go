@@ -14,15 +14,20 @@ import (
 )
 
 const (
-	loginPath         = "golfer_login.json"
-	logoutPath        = "users/logout.json"
-	baseURL           = "https://api2.ghin.com/api/v1/"
-	maxScoresPath     = "maximum_hole_scores.json"
-	lookupPath        = "golfers.json"
-	searchGolfersPath = "golfers/search.json"
-	searchCoursePath  = "crsCourseMethods.asmx/SearchCourses.json"
-	courseDetailsPath = "crsCourseMethods.asmx/GetCourseDetails.json"
-	postScorePath     = "scores/hbh.json"
+	loginPath           = "golfer_login.json"
+	logoutPath          = "users/logout.json"
+	baseURL             = "https://api2.ghin.com/api/v1/"
+	maxScoresPath       = "maximum_hole_scores.json"
+	lookupPath          = "golfers.json"
+	searchGolfersPath   = "golfers/search.json"
+	searchCoursePath    = "crsCourseMethods.asmx/SearchCourses.json"
+	courseDetailsPath   = "crsCourseMethods.asmx/GetCourseDetails.json"
+	postScorePath       = "scores/hbh.json"
+	postTotalScorePath  = "scores/adjusted.json"
+	scorePath           = "scores/%d.json"
+	scoresPath          = "scores.json"
+	updateScorePath     = "scores/%d/hbh.json"
+	handicapHistoryPath = "golfers/%s/handicap_history.json"
 )
 
 type (
@@ -174,6 +179,35 @@ func (c *httpClient) Get(ctx context.Context, path string, params url.Values) ([
 	return c.sendRequest(req)
 }
 
+func (c *httpClient) Put(ctx context.Context, path string, body []byte) ([]byte, error) {
+	requestURL, err := url.JoinPath(c.baseURL, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem building request url")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, requestURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "problem creating request to send to server")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	return c.sendRequest(req)
+}
+
+func (c *httpClient) Delete(ctx context.Context, path string) ([]byte, error) {
+	requestURL, err := url.JoinPath(c.baseURL, path)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem building request url")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, requestURL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "problem creating request to send to server")
+	}
+	req.Header.Set("Accept", "application/json")
+
+	return c.sendRequest(req)
+}
+
 // sendRequest sends a single http.Request and verifies that the response code is valid.
 func (c *httpClient) sendRequest(r *http.Request) ([]byte, error) {
 	// Set the auth token of the request
@@ -204,6 +238,7 @@ func (c *httpClient) sendRequest(r *http.Request) ([]byte, error) {
 }
 
 var successfulResponseCodes = map[int]bool{
-	http.StatusOK:      true,
-	http.StatusCreated: true,
+	http.StatusOK:        true,
+	http.StatusCreated:   true,
+	http.StatusNoContent: true,
 }
@@ -107,3 +107,33 @@ const (
 	TeeSetRatingTypeFront TeeSetRatingType = "Front"
 	TeeSetRatingTypeBack  TeeSetRatingType = "Back"
 )
+
+// TeeSet returns the tee set of the course with the given ID, or nil if the course has no such tee set.
+func (c *CourseDetails) TeeSet(teeSetID int) *TeeSetDetails {
+	for i := range c.TeeSets {
+		if c.TeeSets[i].TeeSetRatingId == teeSetID {
+			return &c.TeeSets[i]
+		}
+	}
+	return nil
+}
+
+// Rating returns the rating of the tee set of the given type, or nil if the tee set is not rated for it.
+func (t *TeeSetDetails) Rating(ratingType TeeSetRatingType) *TeeSetRating {
+	for i := range t.Ratings {
+		if t.Ratings[i].TeeSetRatingType == string(ratingType) {
+			return &t.Ratings[i]
+		}
+	}
+	return nil
+}
+
+// Hole returns the details of the hole with the given number, or nil if the tee set has no such hole.
+func (t *TeeSetDetails) Hole(number int) *HoleDetails {
+	for i := range t.Holes {
+		if t.Holes[i].Number == number {
+			return &t.Holes[i]
+		}
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package ghin
 
 import (
 	"fmt"
+	"strings"
 )
 
 type UserNotLoggedInError struct {
@@ -15,3 +16,41 @@ func NewUserNotLoggedInError(msg string) error {
 func (e UserNotLoggedInError) Error() string {
 	return fmt.Sprintf("user is not logged in: %q", e.Msg)
 }
+
+type GolferNotFoundError struct {
+	GhinNumber string
+}
+
+func NewGolferNotFoundError(ghinNumber string) error {
+	return &GolferNotFoundError{GhinNumber: ghinNumber}
+}
+
+func (e GolferNotFoundError) Error() string {
+	return fmt.Sprintf("no golfer found with GHIN number %q", e.GhinNumber)
+}
+
+// FieldError describes a problem with a single field of a request.
+type FieldError struct {
+	Field string
+	Msg   string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Msg)
+}
+
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func NewValidationError(fields []FieldError) error {
+	return &ValidationError{Fields: fields}
+}
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+	return fmt.Sprintf("validation failed: %s", strings.Join(msgs, "; "))
+}
@@ -0,0 +1,224 @@
+// Package formats scores rounds played under the formats of competitions and side games, such as Stableford, from
+// the same hole scores that are posted to GHIN.
+package formats
+
+import (
+	"sort"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+// Scoring selects whether the strokes a player receives are deducted from their hole scores.
+type Scoring string
+
+const (
+	ScoringGross Scoring = "Gross"
+	ScoringNet   Scoring = "Net"
+)
+
+// Format is a format of play scored by the package.
+type Format string
+
+const (
+	FormatStableford Format = "Stableford"
+	FormatMatchPlay  Format = "MatchPlay"
+	FormatSkins      Format = "Skins"
+	FormatNassau     Format = "Nassau"
+	FormatBestBall   Format = "BestBall"
+	FormatScramble   Format = "Scramble"
+	FormatShamble    Format = "Shamble"
+	FormatAggregate  Format = "Aggregate"
+)
+
+// Postable returns whether the individual scores of a round played in the format are acceptable for handicap
+// purposes, and so can be posted with SubmitScore. The Rules of Handicapping only accept scores where each player
+// plays their own ball throughout the round, which excludes scrambles and shambles.
+func (f Format) Postable() bool {
+	switch f {
+	case FormatScramble, FormatShamble:
+		return false
+	}
+	return true
+}
+
+// Player is the scorecard of a player for a round.
+type Player struct {
+	ID   string
+	Name string
+	// PlayingHandicap is the number of strokes the player receives over the round, negative for plus handicaps.
+	PlayingHandicap int
+	Holes           []ghin.HoleScore
+
+	/* TeeSetSide is the side of the tee set the round is played on. Strokes are allocated over every hole of the
+	 * side, including those the player has not entered yet.
+	 * Default: TeeSetSide18, every hole of the tee set
+	 */
+	TeeSetSide *ghin.TeeSetSide
+}
+
+// Hole is the score of a player on a hole once the strokes they receive have been allocated.
+type Hole struct {
+	Number int
+	Par    int
+	// Allocation is the stroke index of the hole from the tee set, 1 being the hardest hole.
+	Allocation int
+	// Gross is the raw score of the hole, zero when the player picked up.
+	Gross int
+	// Strokes is the number of strokes received on the hole, negative when strokes are given back.
+	Strokes int
+}
+
+// Card is the scorecard of a player with the strokes they receive allocated to each hole.
+type Card struct {
+	Player Player
+	// Holes are in the order of the hole scores of the player.
+	Holes []Hole
+}
+
+// PickedUp returns whether the player did not hole out.
+func (h Hole) PickedUp() bool {
+	return h.Gross == 0
+}
+
+// Net returns the gross score less the strokes received.
+func (h Hole) Net() int {
+	return h.Gross - h.Strokes
+}
+
+// Score returns the gross or net score of the hole.
+func (h Hole) Score(scoring Scoring) int {
+	if scoring == ScoringNet {
+		return h.Net()
+	}
+	return h.Gross
+}
+
+// NewCard allocates the playing handicap of the player to their holes using the stroke allocation of the tee set.
+// The holes of the side played are ranked by allocation, so a nine hole round receives its strokes on the hardest of
+// its nine holes whether or not every hole has been entered. Pars are taken from the tee set, or from the hole scores
+// when the tee set has none.
+func NewCard(player Player, tee ghin.TeeSetDetails) (*Card, error) {
+	side := ghin.TeeSetSide18
+	if player.TeeSetSide != nil {
+		side = *player.TeeSetSide
+	}
+	round, err := sideHoles(tee, side)
+	if err != nil {
+		return nil, err
+	}
+	indexes := strokeIndexes(round)
+	for _, h := range player.Holes {
+		if tee.Hole(h.HoleNumber) != nil {
+			if _, ok := indexes[h.HoleNumber]; !ok {
+				return nil, errors.Errorf("hole %d is not played on tee set side %q", h.HoleNumber, side)
+			}
+		}
+	}
+	return newCard(player, tee, indexes)
+}
+
+// sideHoles returns the holes of the tee set that are played on the side.
+func sideHoles(tee ghin.TeeSetDetails, side ghin.TeeSetSide) ([]ghin.HoleDetails, error) {
+	var out []ghin.HoleDetails
+	for _, h := range tee.Holes {
+		switch side {
+		case ghin.TeeSetSide18:
+			out = append(out, h)
+		case ghin.TeeSetSideFront:
+			if h.Number <= 9 {
+				out = append(out, h)
+			}
+		case ghin.TeeSetSideBack:
+			if h.Number >= 10 {
+				out = append(out, h)
+			}
+		default:
+			return nil, errors.Errorf("unknown tee set side %q", side)
+		}
+	}
+	if len(out) == 0 {
+		return nil, errors.Errorf("tee set %d has no holes on side %q", tee.TeeSetRatingId, side)
+	}
+	return out, nil
+}
+
+// newCard allocates the playing handicap of the player to their holes using the stroke index of each hole within the
+// round. Holes that are not part of the round receive no strokes.
+func newCard(player Player, tee ghin.TeeSetDetails, indexes map[int]int) (*Card, error) {
+	out := &Card{Player: player}
+	for _, h := range player.Holes {
+		details := tee.Hole(h.HoleNumber)
+		if details == nil {
+			return nil, errors.Errorf("hole %d is not on tee set %d", h.HoleNumber, tee.TeeSetRatingId)
+		}
+		par := details.Par
+		if par == 0 {
+			par = h.Par
+		}
+		hole := Hole{
+			Number:     h.HoleNumber,
+			Par:        par,
+			Allocation: details.Allocation,
+			Gross:      h.RawScore,
+		}
+		if index, ok := indexes[h.HoleNumber]; ok {
+			hole.Strokes = StrokesReceived(player.PlayingHandicap, index, len(indexes))
+		}
+		out.Holes = append(out.Holes, hole)
+	}
+	return out, nil
+}
+
+// strokeIndexes ranks the holes of a round by allocation, 1 being the hardest hole, keyed by hole number.
+func strokeIndexes(holes []ghin.HoleDetails) map[int]int {
+	var unique []ghin.HoleDetails
+	seen := map[int]bool{}
+	for _, h := range holes {
+		if !seen[h.Number] {
+			seen[h.Number] = true
+			unique = append(unique, h)
+		}
+	}
+	sort.SliceStable(unique, func(i, j int) bool {
+		return unique[i].Allocation < unique[j].Allocation
+	})
+
+	out := make(map[int]int, len(unique))
+	for i, h := range unique {
+		out[h.Number] = i + 1
+	}
+	return out
+}
+
+// Hole returns the hole with the given number, or nil if the player has no score for it.
+func (c *Card) Hole(number int) *Hole {
+	for i := range c.Holes {
+		if c.Holes[i].Number == number {
+			return &c.Holes[i]
+		}
+	}
+	return nil
+}
+
+// StrokesReceived returns the number of strokes a player with the playing handicap receives on the hole ranked
+// strokeIndex of a round of the given number of holes. A plus handicap gives strokes back starting with the easiest
+// hole.
+func StrokesReceived(playingHandicap, strokeIndex, holes int) int {
+	if holes <= 0 {
+		return 0
+	}
+	if playingHandicap < 0 {
+		given := -playingHandicap
+		out := -(given / holes)
+		if strokeIndex > holes-given%holes {
+			out--
+		}
+		return out
+	}
+	out := playingHandicap / holes
+	if strokeIndex <= playingHandicap%holes {
+		out++
+	}
+	return out
+}
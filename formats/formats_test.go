@@ -0,0 +1,209 @@
+package formats
+
+import (
+	"testing"
+
+	"github.com/C-Deck/ghin"
+)
+
+// testTee returns a tee set of par 4 holes whose allocation is their hole number.
+func testTee(holes int) ghin.TeeSetDetails {
+	tee := ghin.TeeSetDetails{TeeSetRatingId: 1}
+	for i := 1; i <= holes; i++ {
+		tee.Holes = append(tee.Holes, ghin.HoleDetails{Number: i, Par: 4, Allocation: i})
+	}
+	return tee
+}
+
+// testHoles returns hole scores numbered from 1 in the order of the scores.
+func testHoles(scores ...int) []ghin.HoleScore {
+	out := make([]ghin.HoleScore, len(scores))
+	for i, s := range scores {
+		out[i] = ghin.HoleScore{HoleNumber: i%18 + 1, RawScore: s}
+	}
+	return out
+}
+
+// repeat returns count copies of score.
+func repeat(score, count int) []int {
+	out := make([]int, count)
+	for i := range out {
+		out[i] = score
+	}
+	return out
+}
+
+func TestStrokesReceived(t *testing.T) {
+	tests := []struct {
+		name            string
+		playingHandicap int
+		strokeIndex     int
+		holes           int
+		want            int
+	}{
+		{name: "scratch", playingHandicap: 0, strokeIndex: 1, holes: 18, want: 0},
+		{name: "stroke on hardest hole", playingHandicap: 1, strokeIndex: 1, holes: 18, want: 1},
+		{name: "no stroke past handicap", playingHandicap: 1, strokeIndex: 2, holes: 18, want: 0},
+		{name: "one per hole", playingHandicap: 18, strokeIndex: 18, holes: 18, want: 1},
+		{name: "second stroke", playingHandicap: 20, strokeIndex: 2, holes: 18, want: 2},
+		{name: "no second stroke", playingHandicap: 20, strokeIndex: 3, holes: 18, want: 1},
+		{name: "plus gives back on easiest hole", playingHandicap: -1, strokeIndex: 18, holes: 18, want: -1},
+		{name: "plus keeps harder holes", playingHandicap: -1, strokeIndex: 17, holes: 18, want: 0},
+		{name: "plus two", playingHandicap: -2, strokeIndex: 17, holes: 18, want: -1},
+		{name: "plus two hardest hole", playingHandicap: -2, strokeIndex: 1, holes: 18, want: 0},
+		{name: "nine holes", playingHandicap: 5, strokeIndex: 5, holes: 9, want: 1},
+		{name: "nine holes past handicap", playingHandicap: 5, strokeIndex: 6, holes: 9, want: 0},
+		{name: "no holes", playingHandicap: 5, strokeIndex: 1, holes: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StrokesReceived(tt.playingHandicap, tt.strokeIndex, tt.holes); got != tt.want {
+				t.Errorf("StrokesReceived(%d, %d, %d) = %d, want %d",
+					tt.playingHandicap, tt.strokeIndex, tt.holes, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewCardRanksNineHoles(t *testing.T) {
+	back := ghin.TeeSetSideBack
+	holes := testHoles(repeat(4, 18)...)[9:]
+	card, err := NewCard(Player{PlayingHandicap: 1, Holes: holes, TeeSetSide: &back}, testTee(18))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Hole 10 has the lowest allocation of the back nine, so it receives the only stroke.
+	for _, h := range card.Holes {
+		want := 0
+		if h.Number == 10 {
+			want = 1
+		}
+		if h.Strokes != want {
+			t.Errorf("hole %d strokes = %d, want %d", h.Number, h.Strokes, want)
+		}
+	}
+}
+
+func TestNewCardMissingHoles(t *testing.T) {
+	tests := []struct {
+		name            string
+		playingHandicap int
+		holes           []ghin.HoleScore
+		want            map[int]int
+	}{
+		{
+			name:            "missing the last hole",
+			playingHandicap: 18,
+			holes:           testHoles(repeat(4, 17)...),
+			want:            map[int]int{1: 1, 17: 1},
+		},
+		{
+			name:            "round in progress",
+			playingHandicap: 18,
+			holes:           testHoles(4, 4, 4),
+			want:            map[int]int{1: 1, 2: 1, 3: 1},
+		},
+		{
+			name:            "round in progress below the handicap",
+			playingHandicap: 2,
+			holes:           testHoles(4, 4, 4),
+			want:            map[int]int{1: 1, 2: 1, 3: 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			card, err := NewCard(Player{PlayingHandicap: tt.playingHandicap, Holes: tt.holes}, testTee(18))
+			if err != nil {
+				t.Fatal(err)
+			}
+			for number, want := range tt.want {
+				if got := card.Hole(number).Strokes; got != want {
+					t.Errorf("hole %d strokes = %d, want %d", number, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewCardUnknownHole(t *testing.T) {
+	front := ghin.TeeSetSideFront
+	tests := []struct {
+		name   string
+		player Player
+		tee    ghin.TeeSetDetails
+	}{
+		{name: "hole not on the tee set", player: Player{Holes: testHoles(repeat(4, 18)...)}, tee: testTee(9)},
+		{name: "hole not on the side", player: Player{Holes: testHoles(repeat(4, 10)...), TeeSetSide: &front},
+			tee: testTee(18)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewCard(tt.player, tt.tee); err == nil {
+				t.Error("expected an error for holes that are not played")
+			}
+		})
+	}
+}
+
+func TestPointTable(t *testing.T) {
+	tests := []struct {
+		name  string
+		table PointTable
+		toPar int
+		want  int
+	}{
+		{name: "standard par", table: StandardPoints, toPar: 0, want: 2},
+		{name: "standard bogey", table: StandardPoints, toPar: 1, want: 1},
+		{name: "standard worse than double", table: StandardPoints, toPar: 5, want: 0},
+		{name: "standard better than condor", table: StandardPoints, toPar: -5, want: 6},
+		{name: "modified birdie", table: ModifiedPoints, toPar: -1, want: 2},
+		{name: "modified triple bogey", table: ModifiedPoints, toPar: 3, want: -3},
+		{name: "sparse table earns next worse key", table: PointTable{2: 0, 0: 2, -2: 5}, toPar: -1, want: 2},
+		{name: "empty table", table: PointTable{}, toPar: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.table.Points(tt.toPar); got != tt.want {
+				t.Errorf("Points(%d) = %d, want %d", tt.toPar, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStableford(t *testing.T) {
+	scores := repeat(4, 18)
+	scores[0] = 3 // birdie
+	scores[1] = 0 // picked up
+	scores[2] = 6 // double bogey
+	player := Player{ID: "a", PlayingHandicap: 2, Holes: testHoles(scores...)}
+
+	gross, err := Stableford(player, testTee(18), ScoringGross, StandardPoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 3 for the birdie, nothing for the pick up and the double bogey, 2 for each of the 15 pars.
+	if gross.Points != 33 {
+		t.Errorf("gross points = %d, want 33", gross.Points)
+	}
+
+	net, err := Stableford(player, testTee(18), ScoringNet, StandardPoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Strokes on holes 1 and 2 turn the birdie into an eagle, and the pick up still earns nothing.
+	if net.Points != 34 {
+		t.Errorf("net points = %d, want 34", net.Points)
+	}
+	if net.Holes[0].ToPar != -2 || net.Holes[0].Points != 4 {
+		t.Errorf("hole 1 = %+v, want a net eagle worth 4 points", net.Holes[0])
+	}
+
+	modified, err := Stableford(player, testTee(18), ScoringGross, ModifiedPoints)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2 for the birdie, -3 for the pick up and the double bogey.
+	if modified.Points != -4 {
+		t.Errorf("modified points = %d, want -4", modified.Points)
+	}
+}
@@ -0,0 +1,285 @@
+package formats
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+// defaultMatchHoles is the number of holes of a match unless stated otherwise.
+const defaultMatchHoles = 18
+
+// Side is a team of players: one side of a match, which is a single player or the two partners of a four-ball whose
+// best net score counts on each hole, or a team of a team format.
+type Side struct {
+	// Name describes the side. The names of its players joined by an ampersand are used when it is empty.
+	Name    string
+	Players []Player
+}
+
+type MatchInput struct {
+	First  Side
+	Second Side
+	// Tee is the tee set the match is played on.
+	Tee ghin.TeeSetDetails
+
+	/* Holes is the number of holes of the stipulated round, starting with the first hole played.
+	 * Default: 18
+	 */
+	Holes *int
+}
+
+// MatchStatus is the standing of a match after a hole.
+type MatchStatus struct {
+	// Up is the number of holes the first side leads by, negative when the second side leads.
+	Up int
+	// ToPlay is the number of holes of the stipulated round left to play.
+	ToPlay int
+}
+
+// MatchHole is the result of a hole of a match.
+type MatchHole struct {
+	Number int
+	// Extra is whether the hole was played after the stipulated round to break a tie.
+	Extra bool
+	// First and Second are the best net score of each side, nil when every player of the side picked up.
+	First  *int
+	Second *int
+	// Winner is 1 or 2 for the side that won the hole and 0 when the hole was halved.
+	Winner int
+	Status MatchStatus
+}
+
+// Match is the result of a match, or its standing when it is still being played.
+type Match struct {
+	First  Side
+	Second Side
+	Holes  []MatchHole
+	// Complete is whether the match has been decided or halved.
+	Complete bool
+	// Winner is 1 or 2 for the side that won the match and 0 when it was halved or is not complete.
+	Winner int
+	// Result describes how the match ended, such as "3&2", "1 UP", "1 UP (20 holes)" or "Halved", or its current
+	// status when it is not complete.
+	Result string
+}
+
+// Dormie returns whether the leading side is up by as many holes as are left to play.
+func (s MatchStatus) Dormie() bool {
+	return s.Up != 0 && abs(s.Up) == s.ToPlay
+}
+
+// Decided returns whether the leading side is up by more holes than are left to play.
+func (s MatchStatus) Decided() bool {
+	return abs(s.Up) > s.ToPlay
+}
+
+func (s MatchStatus) String() string {
+	switch {
+	case s.Up == 0 && s.ToPlay == 0:
+		return "All square"
+	case s.Up == 0:
+		return fmt.Sprintf("All square with %d to play", s.ToPlay)
+	case s.Dormie():
+		return fmt.Sprintf("Dormie %d UP", abs(s.Up))
+	case s.ToPlay == 0:
+		return fmt.Sprintf("%d UP", abs(s.Up))
+	}
+	return fmt.Sprintf("%d UP with %d to play", abs(s.Up), s.ToPlay)
+}
+
+// DisplayName returns the name of the side.
+func (s Side) DisplayName() string {
+	if s.Name != "" {
+		return s.Name
+	}
+	names := make([]string, len(s.Players))
+	for i, p := range s.Players {
+		names[i] = p.Name
+	}
+	return strings.Join(names, " & ")
+}
+
+// Status returns the standing of the match after the last hole played.
+func (m *Match) Status() MatchStatus {
+	if len(m.Holes) == 0 {
+		return MatchStatus{}
+	}
+	return m.Holes[len(m.Holes)-1].Status
+}
+
+// Leader returns the side leading the match, or nil when it is all square.
+func (m *Match) Leader() *Side {
+	switch status := m.Status(); {
+	case status.Up > 0:
+		return &m.First
+	case status.Up < 0:
+		return &m.Second
+	}
+	return nil
+}
+
+// MatchPlay plays the hole scores of a singles or four-ball match. Each player receives the difference between their
+// playing handicap and the lowest playing handicap of the match, allocated by the stroke allocation of the holes of
+// the stipulated round. Hole scores are matched by position, so scores past the end of the stipulated round are
+// extra holes and receive strokes as they did in the stipulated round. A side whose players all picked up loses the
+// hole unless the other side also picked up. Playing stops at the first hole either side has not entered a score
+// for, leaving the match incomplete.
+func MatchPlay(input MatchInput) (*Match, error) {
+	sides := []Side{input.First, input.Second}
+	for i, side := range sides {
+		if len(side.Players) != 1 && len(side.Players) != 2 {
+			return nil, errors.Errorf("side %d must have 1 or 2 players, got %d", i+1, len(side.Players))
+		}
+		if len(side.Players[0].Holes) == 0 {
+			return nil, errors.Errorf("player %s of side %d has no hole scores", side.Players[0].ID, i+1)
+		}
+	}
+	if len(input.First.Players) != len(input.Second.Players) {
+		return nil, errors.Errorf("sides must have the same number of players, got %d and %d",
+			len(input.First.Players), len(input.Second.Players))
+	}
+
+	regulation := defaultMatchHoles
+	if input.Holes != nil {
+		regulation = *input.Holes
+	}
+	round, err := stipulatedRound(input.Tee, input.First.Players[0].Holes[0].HoleNumber, regulation)
+	if err != nil {
+		return nil, err
+	}
+	indexes := strokeIndexes(round)
+
+	low := input.First.Players[0].PlayingHandicap
+	for _, side := range sides {
+		for _, p := range side.Players {
+			if p.PlayingHandicap < low {
+				low = p.PlayingHandicap
+			}
+		}
+	}
+	cards := make([][]*Card, len(sides))
+	positions := 0
+	for i, side := range sides {
+		for _, p := range side.Players {
+			p.PlayingHandicap -= low
+			card, err := newCard(p, input.Tee, indexes)
+			if err != nil {
+				return nil, errors.Wrapf(err, "problem allocating strokes of player %s", p.ID)
+			}
+			cards[i] = append(cards[i], card)
+			if len(card.Holes) > positions {
+				positions = len(card.Holes)
+			}
+		}
+	}
+
+	out := &Match{First: input.First, Second: input.Second}
+	var status MatchStatus
+	for i := 0; i < positions; i++ {
+		first, number, firstPlayed := bestNet(cards[0], i)
+		second, _, secondPlayed := bestNet(cards[1], i)
+		if !firstPlayed || !secondPlayed {
+			break
+		}
+
+		hole := MatchHole{Number: number, Extra: i >= regulation, First: first, Second: second}
+		switch {
+		case first == nil && second == nil, first != nil && second != nil && *first == *second:
+		case second == nil || (first != nil && *first < *second):
+			hole.Winner = 1
+			status.Up++
+		default:
+			hole.Winner = 2
+			status.Up--
+		}
+		status.ToPlay = regulation - i - 1
+		if status.ToPlay < 0 {
+			status.ToPlay = 0
+		}
+		hole.Status = status
+		out.Holes = append(out.Holes, hole)
+
+		if status.Decided() {
+			out.Complete = true
+			break
+		}
+	}
+
+	switch {
+	case out.Complete && len(out.Holes) > regulation:
+		out.Result = fmt.Sprintf("1 UP (%d holes)", len(out.Holes))
+	case out.Complete && status.ToPlay > 0:
+		out.Result = fmt.Sprintf("%d&%d", abs(status.Up), status.ToPlay)
+	case len(out.Holes) == regulation && status.Up == 0:
+		out.Complete = true
+		out.Result = "Halved"
+	default:
+		out.Result = status.String()
+	}
+	if out.Complete && status.Up > 0 {
+		out.Winner = 1
+	} else if out.Complete && status.Up < 0 {
+		out.Winner = 2
+	}
+	return out, nil
+}
+
+// stipulatedRound returns the holes of the tee set that make up a round of the given number of holes starting with
+// the hole numbered start, wrapping around to the first hole of the tee set.
+func stipulatedRound(tee ghin.TeeSetDetails, start, holes int) ([]ghin.HoleDetails, error) {
+	ordered := append([]ghin.HoleDetails(nil), tee.Holes...)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].Number < ordered[j].Number
+	})
+	if holes <= 0 || holes > len(ordered) {
+		return nil, errors.Errorf("a round on tee set %d must have between 1 and %d holes, got %d",
+			tee.TeeSetRatingId, len(ordered), holes)
+	}
+
+	first := -1
+	for i, h := range ordered {
+		if h.Number == start {
+			first = i
+		}
+	}
+	if first < 0 {
+		return nil, errors.Errorf("hole %d is not on tee set %d", start, tee.TeeSetRatingId)
+	}
+
+	out := make([]ghin.HoleDetails, holes)
+	for i := range out {
+		out[i] = ordered[(first+i)%len(ordered)]
+	}
+	return out, nil
+}
+
+// bestNet returns the best net score of the cards on the hole at the position, the number of the hole, and whether
+// any of the cards has a score for it. The score is nil when every player picked up.
+func bestNet(cards []*Card, position int) (*int, int, bool) {
+	var best *int
+	var number int
+	var played bool
+	for _, c := range cards {
+		if position >= len(c.Holes) {
+			continue
+		}
+		h := c.Holes[position]
+		played = true
+		number = h.Number
+		if net := h.Net(); !h.PickedUp() && (best == nil || net < *best) {
+			best = &net
+		}
+	}
+	return best, number, played
+}
+
+func abs(v int) int {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
@@ -0,0 +1,176 @@
+package formats
+
+import (
+	"testing"
+)
+
+func singles(first, second Player) MatchInput {
+	return MatchInput{
+		First:  Side{Players: []Player{first}},
+		Second: Side{Players: []Player{second}},
+		Tee:    testTee(18),
+	}
+}
+
+func TestMatchPlay(t *testing.T) {
+	pars := repeat(4, 18)
+	winsFirstThree := append([]int{3, 3, 3}, repeat(4, 15)...)
+	winsFirstTwo := append([]int{3, 3}, repeat(4, 16)...)
+	winsLast := append(repeat(4, 17), 3)
+
+	tests := []struct {
+		name         string
+		input        MatchInput
+		wantResult   string
+		wantComplete bool
+		wantWinner   int
+		wantHoles    int
+	}{
+		{
+			name:         "halved",
+			input:        singles(Player{Holes: testHoles(pars...)}, Player{Holes: testHoles(pars...)}),
+			wantResult:   "Halved",
+			wantComplete: true,
+			wantHoles:    18,
+		},
+		{
+			name:         "won on the last hole",
+			input:        singles(Player{Holes: testHoles(pars...)}, Player{Holes: testHoles(winsLast...)}),
+			wantResult:   "1 UP",
+			wantComplete: true,
+			wantWinner:   2,
+			wantHoles:    18,
+		},
+		{
+			name: "decided early",
+			input: singles(
+				Player{Holes: testHoles(append([]int{3, 3, 3, 3}, repeat(4, 14)...)...)},
+				Player{Holes: testHoles(pars...)},
+			),
+			wantResult:   "4&3",
+			wantComplete: true,
+			wantWinner:   1,
+			wantHoles:    15,
+		},
+		{
+			name: "extra holes",
+			input: singles(
+				Player{Holes: testHoles(append(repeat(4, 18), 4, 3)...)},
+				Player{Holes: testHoles(append(repeat(4, 18), 4, 4)...)},
+			),
+			wantResult:   "1 UP (20 holes)",
+			wantComplete: true,
+			wantWinner:   1,
+			wantHoles:    20,
+		},
+		{
+			name:       "side that has not entered a hole stops the match",
+			input:      singles(Player{Holes: testHoles(pars...)}, Player{Holes: testHoles(repeat(4, 10)...)}),
+			wantResult: "All square with 8 to play",
+			wantHoles:  10,
+		},
+		{
+			name: "pick up loses the hole",
+			input: singles(
+				Player{Holes: testHoles(append([]int{0}, repeat(4, 3)...)...)},
+				Player{Holes: testHoles(repeat(4, 4)...)},
+			),
+			wantResult: "1 UP with 14 to play",
+			wantHoles:  4,
+		},
+		{
+			name: "strokes are the difference of the playing handicaps",
+			input: singles(
+				Player{PlayingHandicap: 4, Holes: testHoles(pars...)},
+				Player{PlayingHandicap: 2, Holes: testHoles(pars...)},
+			),
+			wantResult:   "2&1",
+			wantComplete: true,
+			wantWinner:   1,
+			wantHoles:    17,
+		},
+		{
+			name: "four-ball counts the best net score of each side",
+			input: MatchInput{
+				First: Side{Players: []Player{
+					{Holes: testHoles(winsFirstThree...)},
+					{Holes: testHoles(pars...)},
+				}},
+				Second: Side{Players: []Player{
+					{Holes: testHoles(pars...)},
+					{Holes: testHoles(winsFirstTwo...)},
+				}},
+				Tee: testTee(18),
+			},
+			wantResult:   "1 UP",
+			wantComplete: true,
+			wantWinner:   1,
+			wantHoles:    18,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match, err := MatchPlay(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if match.Result != tt.wantResult || match.Complete != tt.wantComplete || match.Winner != tt.wantWinner ||
+				len(match.Holes) != tt.wantHoles {
+				t.Errorf("got result %q, complete %t, winner %d after %d holes, want %q, %t, %d after %d holes",
+					match.Result, match.Complete, match.Winner, len(match.Holes),
+					tt.wantResult, tt.wantComplete, tt.wantWinner, tt.wantHoles)
+			}
+		})
+	}
+}
+
+func TestMatchStatus(t *testing.T) {
+	tests := []struct {
+		status      MatchStatus
+		wantDormie  bool
+		wantDecided bool
+		wantString  string
+	}{
+		{status: MatchStatus{Up: 0, ToPlay: 5}, wantString: "All square with 5 to play"},
+		{status: MatchStatus{Up: 2, ToPlay: 3}, wantString: "2 UP with 3 to play"},
+		{status: MatchStatus{Up: -2, ToPlay: 2}, wantDormie: true, wantString: "Dormie 2 UP"},
+		{status: MatchStatus{Up: 3, ToPlay: 2}, wantDecided: true, wantString: "3 UP with 2 to play"},
+		{status: MatchStatus{Up: 1, ToPlay: 0}, wantDecided: true, wantString: "1 UP"},
+		{status: MatchStatus{}, wantString: "All square"},
+	}
+	for _, tt := range tests {
+		if got := tt.status.Dormie(); got != tt.wantDormie {
+			t.Errorf("%+v Dormie() = %t, want %t", tt.status, got, tt.wantDormie)
+		}
+		if got := tt.status.Decided(); got != tt.wantDecided {
+			t.Errorf("%+v Decided() = %t, want %t", tt.status, got, tt.wantDecided)
+		}
+		if got := tt.status.String(); got != tt.wantString {
+			t.Errorf("%+v String() = %q, want %q", tt.status, got, tt.wantString)
+		}
+	}
+}
+
+func TestMatchPlayDormie(t *testing.T) {
+	match, err := MatchPlay(singles(
+		Player{Holes: testHoles(append([]int{3, 3}, repeat(4, 16)...)...)},
+		Player{Holes: testHoles(repeat(4, 18)...)},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status := match.Holes[15].Status; !status.Dormie() {
+		t.Errorf("status after hole 16 = %+v, want dormie", status)
+	}
+	if match.Result != "2&1" || match.Leader() != &match.First {
+		t.Errorf("result = %q, want 2&1 for the first side", match.Result)
+	}
+}
+
+func TestMatchPlayRejectsUnevenSides(t *testing.T) {
+	input := singles(Player{Holes: testHoles(4)}, Player{Holes: testHoles(4)})
+	input.Second.Players = append(input.Second.Players, Player{Holes: testHoles(4)})
+	if _, err := MatchPlay(input); err == nil {
+		t.Error("expected an error for sides of different sizes")
+	}
+}
@@ -0,0 +1,308 @@
+package formats
+
+import (
+	"math"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+// NassauSegment is the part of the round a bet of a Nassau is played over.
+type NassauSegment string
+
+const (
+	NassauFront   NassauSegment = "Front"
+	NassauBack    NassauSegment = "Back"
+	NassauOverall NassauSegment = "Overall"
+)
+
+// nassauSegmentHoles is the number of holes of the front and back segments.
+const nassauSegmentHoles = 9
+
+// Press is a new bet called by a player who is down, played from a hole to the end of the segment of the bet.
+type Press struct {
+	// By is the ID of the player calling the press and Against the ID of their opponent.
+	By      string
+	Against string
+	Segment NassauSegment
+	// Hole is the number of the hole the press starts on.
+	Hole int
+}
+
+type NassauInput struct {
+	// Players each play a Nassau against every other player of the group.
+	Players []Player
+	// Tee is the tee set the players played.
+	Tee ghin.TeeSetDetails
+	// Stake is the amount of each bet, including presses.
+	Stake float64
+	// Presses are the presses called by the players.
+	Presses []Press
+
+	/* AutoPress is the number of holes down in a bet that automatically starts a press on the next hole. A bet
+	 * starts at most one automatic press, but presses can start presses of their own.
+	 * Default: No automatic presses
+	 */
+	AutoPress *int
+}
+
+// NassauBet is the result of one bet between two players.
+type NassauBet struct {
+	First   string
+	Second  string
+	Segment NassauSegment
+	// Press is whether the bet is a press, and Automatic whether the press was started by AutoPress.
+	Press     bool
+	Automatic bool
+	// Start and End are the numbers of the first and last holes of the bet.
+	Start int
+	End   int
+	// Up is the number of holes the first player won the bet by, negative when the second player won it.
+	Up int
+	// Winner is the ID of the player who won the bet, empty when it was tied.
+	Winner string
+	Amount float64
+}
+
+// Payment settles what one player owes another.
+type Payment struct {
+	From   string
+	To     string
+	Amount float64
+}
+
+// NassauResult is the result of the Nassaus of a group.
+type NassauResult struct {
+	Bets []NassauBet
+	// Balances are the amounts won by each player, keyed by player ID, negative for the players who lost money.
+	Balances map[string]float64
+	// Ledger settles the bets of each pair of players with a single payment.
+	Ledger []Payment
+}
+
+// nassauBet is a bet being played over the holes at positions start through end of a pair's hole results.
+type nassauBet struct {
+	NassauBet
+	start, end int
+}
+
+// Nassau settles a match play Nassau between every pair of players: one bet on the front nine, one on the back nine
+// and one on the overall round, plus their presses. In each pair the player with the higher playing handicap receives
+// the difference on the holes allocated by the tee set. Holes are matched by position and a pair only plays the
+// holes both players have a score for.
+func Nassau(input NassauInput) (*NassauResult, error) {
+	if len(input.Players) < 2 {
+		return nil, errors.Errorf("at least 2 players are required for a Nassau, got %d", len(input.Players))
+	}
+
+	ids := map[string]bool{}
+	for _, p := range input.Players {
+		ids[p.ID] = true
+	}
+	for _, press := range input.Presses {
+		switch press.Segment {
+		case NassauFront, NassauBack, NassauOverall:
+		default:
+			return nil, errors.Errorf("press by %s on hole %d has unknown segment %q", press.By, press.Hole, press.Segment)
+		}
+		if !ids[press.By] || !ids[press.Against] || press.By == press.Against {
+			return nil, errors.Errorf("press by %s against %s on hole %d must be between two players of the Nassau",
+				press.By, press.Against, press.Hole)
+		}
+	}
+
+	indexes := strokeIndexes(input.Tee.Holes)
+	out := &NassauResult{Balances: map[string]float64{}}
+	for i := range input.Players {
+		for j := i + 1; j < len(input.Players); j++ {
+			first, second := input.Players[i], input.Players[j]
+			results, numbers, err := pairResults(first, second, input.Tee, indexes)
+			if err != nil {
+				return nil, err
+			}
+
+			var bets []nassauBet
+			for _, segment := range []NassauSegment{NassauFront, NassauBack, NassauOverall} {
+				start, end := segmentPositions(segment, len(results))
+				if start <= end {
+					bets = append(bets, newNassauBet(first.ID, second.ID, segment, start, end, numbers))
+				}
+			}
+			for _, press := range input.Presses {
+				if !pairedWith(press, first.ID, second.ID) {
+					continue
+				}
+				start := positionOf(numbers, press.Hole)
+				segmentStart, end := segmentPositions(press.Segment, len(results))
+				if start < 0 || start < segmentStart || start > end {
+					return nil, errors.Errorf("press by %s on hole %d is not within the %s holes played",
+						press.By, press.Hole, press.Segment)
+				}
+				bet := newNassauBet(first.ID, second.ID, press.Segment, start, end, numbers)
+				bet.Press = true
+				bets = append(bets, bet)
+			}
+
+			for k := 0; k < len(bets); k++ {
+				up, pressAt := playBet(bets[k], results, input.AutoPress)
+				if pressAt >= 0 {
+					press := newNassauBet(first.ID, second.ID, bets[k].Segment, pressAt, bets[k].end, numbers)
+					press.Press, press.Automatic = true, true
+					bets = append(bets, press)
+				}
+
+				bet := bets[k].NassauBet
+				bet.Up = up
+				switch {
+				case up > 0:
+					bet.Winner = first.ID
+				case up < 0:
+					bet.Winner = second.ID
+				}
+				if bet.Winner != "" {
+					bet.Amount = input.Stake
+				}
+				out.Bets = append(out.Bets, bet)
+			}
+		}
+	}
+
+	out.Balances, out.Ledger = settle(input.Players, out.Bets)
+	return out, nil
+}
+
+// pairResults plays the holes both players have a score for and returns the result of each, 1 when the first player
+// won it, -1 when the second did and 0 when it was halved, along with the numbers of the holes.
+func pairResults(first, second Player, tee ghin.TeeSetDetails, indexes map[int]int) ([]int, []int, error) {
+	low := first.PlayingHandicap
+	if second.PlayingHandicap < low {
+		low = second.PlayingHandicap
+	}
+	var cards []*Card
+	for _, p := range []Player{first, second} {
+		p.PlayingHandicap -= low
+		card, err := newCard(p, tee, indexes)
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "problem allocating strokes of player %s", p.ID)
+		}
+		cards = append(cards, card)
+	}
+
+	holes := len(cards[0].Holes)
+	if len(cards[1].Holes) < holes {
+		holes = len(cards[1].Holes)
+	}
+	results := make([]int, holes)
+	numbers := make([]int, holes)
+	for i := range results {
+		a, _, _ := bestNet(cards[:1], i)
+		b, _, _ := bestNet(cards[1:], i)
+		switch {
+		case a == nil && b == nil, a != nil && b != nil && *a == *b:
+		case b == nil || (a != nil && *a < *b):
+			results[i] = 1
+		default:
+			results[i] = -1
+		}
+		numbers[i] = cards[0].Holes[i].Number
+	}
+	return results, numbers, nil
+}
+
+// playBet returns the number of holes the first player won the bet by and the position an automatic press starts
+// on, or -1 when the bet does not start one.
+func playBet(bet nassauBet, results []int, autoPress *int) (int, int) {
+	up, pressAt := 0, -1
+	for i := bet.start; i <= bet.end; i++ {
+		up += results[i]
+		if autoPress != nil && pressAt < 0 && abs(up) >= *autoPress && i < bet.end {
+			pressAt = i + 1
+		}
+	}
+	return up, pressAt
+}
+
+func newNassauBet(first, second string, segment NassauSegment, start, end int, numbers []int) nassauBet {
+	return nassauBet{
+		NassauBet: NassauBet{
+			First:   first,
+			Second:  second,
+			Segment: segment,
+			Start:   numbers[start],
+			End:     numbers[end],
+		},
+		start: start,
+		end:   end,
+	}
+}
+
+// segmentPositions returns the first and last positions of the segment within a round of the given number of holes.
+// The first position is after the last when the round does not reach the segment.
+func segmentPositions(segment NassauSegment, holes int) (int, int) {
+	start, end := 0, holes-1
+	switch segment {
+	case NassauFront:
+		if end >= nassauSegmentHoles {
+			end = nassauSegmentHoles - 1
+		}
+	case NassauBack:
+		start = nassauSegmentHoles
+	}
+	return start, end
+}
+
+func pairedWith(press Press, first, second string) bool {
+	return (press.By == first && press.Against == second) || (press.By == second && press.Against == first)
+}
+
+func positionOf(numbers []int, hole int) int {
+	for i, n := range numbers {
+		if n == hole {
+			return i
+		}
+	}
+	return -1
+}
+
+// settle totals the bets won and lost by each player and the net amount owed between each pair of players.
+func settle(players []Player, bets []NassauBet) (map[string]float64, []Payment) {
+	balances := map[string]float64{}
+	owed := map[[2]string]float64{}
+	for _, p := range players {
+		balances[p.ID] = 0
+	}
+	for _, bet := range bets {
+		if bet.Winner == "" {
+			continue
+		}
+		loser := bet.First
+		if bet.Winner == bet.First {
+			loser = bet.Second
+		}
+		balances[bet.Winner] += bet.Amount
+		balances[loser] -= bet.Amount
+		owed[[2]string{loser, bet.Winner}] += bet.Amount
+	}
+
+	var ledger []Payment
+	for i := range players {
+		for j := i + 1; j < len(players); j++ {
+			a, b := players[i].ID, players[j].ID
+			net := owed[[2]string{a, b}] - owed[[2]string{b, a}]
+			switch {
+			case net > 0:
+				ledger = append(ledger, Payment{From: a, To: b, Amount: roundCent(net)})
+			case net < 0:
+				ledger = append(ledger, Payment{From: b, To: a, Amount: roundCent(-net)})
+			}
+		}
+	}
+	for id, balance := range balances {
+		balances[id] = roundCent(balance)
+	}
+	return balances, ledger
+}
+
+func roundCent(v float64) float64 {
+	return math.Round(v*100) / 100
+}
@@ -0,0 +1,146 @@
+package formats
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNassau(t *testing.T) {
+	two := 2
+	// Player a wins holes 1 and 2, then player b wins holes 10 to 13.
+	a := Player{ID: "a", Holes: testHoles(append([]int{3, 3}, repeat(4, 16)...)...)}
+	b := Player{ID: "b", Holes: testHoles(append(append(repeat(4, 9), 3, 3, 3, 3), repeat(4, 5)...)...)}
+
+	tests := []struct {
+		name         string
+		input        NassauInput
+		wantBets     []NassauBet
+		wantBalances map[string]float64
+		wantLedger   []Payment
+	}{
+		{
+			name:  "front, back and overall",
+			input: NassauInput{Players: []Player{a, b}, Tee: testTee(18), Stake: 5},
+			wantBets: []NassauBet{
+				{First: "a", Second: "b", Segment: NassauFront, Start: 1, End: 9, Up: 2, Winner: "a", Amount: 5},
+				{First: "a", Second: "b", Segment: NassauBack, Start: 10, End: 18, Up: -4, Winner: "b", Amount: 5},
+				{First: "a", Second: "b", Segment: NassauOverall, Start: 1, End: 18, Up: -2, Winner: "b", Amount: 5},
+			},
+			wantBalances: map[string]float64{"a": -5, "b": 5},
+			wantLedger:   []Payment{{From: "a", To: "b", Amount: 5}},
+		},
+		{
+			name: "manual press",
+			input: NassauInput{
+				Players: []Player{a, b},
+				Tee:     testTee(18),
+				Stake:   5,
+				Presses: []Press{{By: "a", Against: "b", Segment: NassauBack, Hole: 12}},
+			},
+			wantBets: []NassauBet{
+				{First: "a", Second: "b", Segment: NassauFront, Start: 1, End: 9, Up: 2, Winner: "a", Amount: 5},
+				{First: "a", Second: "b", Segment: NassauBack, Start: 10, End: 18, Up: -4, Winner: "b", Amount: 5},
+				{First: "a", Second: "b", Segment: NassauOverall, Start: 1, End: 18, Up: -2, Winner: "b", Amount: 5},
+				{First: "a", Second: "b", Segment: NassauBack, Press: true, Start: 12, End: 18, Up: -2, Winner: "b",
+					Amount: 5},
+			},
+			wantBalances: map[string]float64{"a": -10, "b": 10},
+			wantLedger:   []Payment{{From: "a", To: "b", Amount: 10}},
+		},
+		{
+			name:  "automatic presses",
+			input: NassauInput{Players: []Player{a, b}, Tee: testTee(18), Stake: 5, AutoPress: &two},
+			wantBets: []NassauBet{
+				{First: "a", Second: "b", Segment: NassauFront, Start: 1, End: 9, Up: 2, Winner: "a", Amount: 5},
+				{First: "a", Second: "b", Segment: NassauBack, Start: 10, End: 18, Up: -4, Winner: "b", Amount: 5},
+				{First: "a", Second: "b", Segment: NassauOverall, Start: 1, End: 18, Up: -2, Winner: "b", Amount: 5},
+				// Front and overall are 2 up after hole 2, back is 2 down after hole 11.
+				{First: "a", Second: "b", Segment: NassauFront, Press: true, Automatic: true, Start: 3, End: 9},
+				{First: "a", Second: "b", Segment: NassauBack, Press: true, Automatic: true, Start: 12, End: 18,
+					Up: -2, Winner: "b", Amount: 5},
+				{First: "a", Second: "b", Segment: NassauOverall, Press: true, Automatic: true, Start: 3, End: 18,
+					Up: -4, Winner: "b", Amount: 5},
+				// The back press is 2 down after hole 13 and halves the rest.
+				{First: "a", Second: "b", Segment: NassauBack, Press: true, Automatic: true, Start: 14, End: 18},
+				// The overall press is 2 down after hole 11.
+				{First: "a", Second: "b", Segment: NassauOverall, Press: true, Automatic: true, Start: 12, End: 18,
+					Up: -2, Winner: "b", Amount: 5},
+				{First: "a", Second: "b", Segment: NassauOverall, Press: true, Automatic: true, Start: 14, End: 18},
+			},
+			wantBalances: map[string]float64{"a": -20, "b": 20},
+			wantLedger:   []Payment{{From: "a", To: "b", Amount: 20}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Nassau(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result.Bets, tt.wantBets) {
+				t.Errorf("bets =\n%+v\nwant\n%+v", result.Bets, tt.wantBets)
+			}
+			if !reflect.DeepEqual(result.Balances, tt.wantBalances) {
+				t.Errorf("balances = %v, want %v", result.Balances, tt.wantBalances)
+			}
+			if !reflect.DeepEqual(result.Ledger, tt.wantLedger) {
+				t.Errorf("ledger = %+v, want %+v", result.Ledger, tt.wantLedger)
+			}
+		})
+	}
+}
+
+func TestNassauLedger(t *testing.T) {
+	// a wins the front and overall against b and every bet against c, and b wins every bet against c.
+	result, err := Nassau(NassauInput{
+		Players: []Player{
+			{ID: "a", Holes: testHoles(append([]int{3}, repeat(4, 17)...)...)},
+			{ID: "b", Holes: testHoles(repeat(4, 18)...)},
+			{ID: "c", Holes: testHoles(repeat(5, 18)...)},
+		},
+		Tee:   testTee(18),
+		Stake: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantLedger := []Payment{
+		{From: "b", To: "a", Amount: 4},
+		{From: "c", To: "a", Amount: 6},
+		{From: "c", To: "b", Amount: 6},
+	}
+	if !reflect.DeepEqual(result.Ledger, wantLedger) {
+		t.Errorf("ledger = %+v, want %+v", result.Ledger, wantLedger)
+	}
+	wantBalances := map[string]float64{"a": 10, "b": 2, "c": -12}
+	if !reflect.DeepEqual(result.Balances, wantBalances) {
+		t.Errorf("balances = %v, want %v", result.Balances, wantBalances)
+	}
+}
+
+func TestNassauRejectsInvalidPresses(t *testing.T) {
+	players := []Player{
+		{ID: "a", Holes: testHoles(repeat(4, 18)...)},
+		{ID: "b", Holes: testHoles(repeat(4, 18)...)},
+	}
+	tests := []struct {
+		name  string
+		press Press
+	}{
+		{name: "back press on the front nine", press: Press{By: "a", Against: "b", Segment: NassauBack, Hole: 5}},
+		{name: "front press on the back nine", press: Press{By: "a", Against: "b", Segment: NassauFront, Hole: 12}},
+		{name: "hole not played", press: Press{By: "a", Against: "b", Segment: NassauOverall, Hole: 19}},
+		{name: "unknown segment", press: Press{By: "a", Against: "b", Segment: "Middle", Hole: 5}},
+		{name: "unknown player", press: Press{By: "a", Against: "c", Segment: NassauFront, Hole: 5}},
+		{name: "no opponent", press: Press{By: "a", Segment: NassauFront, Hole: 5}},
+		{name: "against themselves", press: Press{By: "b", Against: "b", Segment: NassauFront, Hole: 5}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Nassau(NassauInput{Players: players, Tee: testTee(18), Stake: 1, Presses: []Press{tt.press}})
+			if err == nil {
+				t.Errorf("expected an error for press %+v", tt.press)
+			}
+		})
+	}
+}
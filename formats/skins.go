@@ -0,0 +1,156 @@
+package formats
+
+import (
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+type SkinsInput struct {
+	Players []Player
+	// Tee is the tee set the players played.
+	Tee     ghin.TeeSetDetails
+	Scoring Scoring
+
+	/* Carryover is whether the skin of a tied hole is added to the next hole.
+	 * Default: true
+	 */
+	Carryover *bool
+	/* ValidateBirdies is whether a skin won with a birdie or better must be validated by a par or better on the next
+	 * hole. A skin that is not validated is treated as if the hole was tied.
+	 * Default: false
+	 */
+	ValidateBirdies *bool
+	/* Pot is the amount shared by the skins won.
+	 * Default: No payouts are calculated
+	 */
+	Pot *float64
+}
+
+// SkinsHole is the result of a hole of a skins game.
+type SkinsHole struct {
+	Number int
+	// Value is the number of skins at stake on the hole, including skins carried over from previous holes.
+	Value int
+	// Winner is the ID of the player with the lowest score, empty when the lowest score was tied.
+	Winner string
+	// Score is the lowest gross or net score of the hole.
+	Score int
+	// Validated is whether the skins of the hole were awarded to the winner. It is false when the hole was tied or the
+	// winner failed to validate a birdie.
+	Validated bool
+}
+
+// SkinsResult is the result of a skins game.
+type SkinsResult struct {
+	Holes []SkinsHole
+	// Skins are the number of skins won by each player, keyed by player ID.
+	Skins map[string]int
+	// Unclaimed is the number of skins that were not won, because they were still carried over after the last hole
+	// or were lost without carryover.
+	Unclaimed int
+	// SkinValue is the share of the pot won by each skin.
+	SkinValue float64
+	// Payouts are the share of the pot won by each player, keyed by player ID, rounded to the cent.
+	Payouts map[string]float64
+}
+
+// Skins plays a skins game over the hole scores of the players. The holes are played in the order of the hole scores
+// of the first player and a hole is won by the only player with the lowest gross or net score on it. Players without
+// a score for a hole, or who picked up, cannot win it.
+func Skins(input SkinsInput) (*SkinsResult, error) {
+	if len(input.Players) < 2 {
+		return nil, errors.Errorf("at least 2 players are required for skins, got %d", len(input.Players))
+	}
+	carryover := input.Carryover == nil || *input.Carryover
+	validate := input.ValidateBirdies != nil && *input.ValidateBirdies
+
+	cards := make([]*Card, len(input.Players))
+	for i, p := range input.Players {
+		card, err := NewCard(p, input.Tee)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem allocating strokes of player %s", p.ID)
+		}
+		cards[i] = card
+	}
+
+	out := &SkinsResult{Skins: map[string]int{}}
+	carried := 0
+	// pending is the index in out.Holes of a skin that is waiting to be validated on the next hole.
+	pending := -1
+	for _, first := range cards[0].Holes {
+		if pending >= 0 {
+			won := out.Holes[pending]
+			if h := cardOf(cards, won.Winner).Hole(first.Number); h != nil && !h.PickedUp() &&
+				h.Score(input.Scoring) <= h.Par {
+				out.Holes[pending].Validated = true
+				out.Skins[won.Winner] += won.Value
+			} else if carryover {
+				carried += won.Value
+			} else {
+				out.Unclaimed += won.Value
+			}
+			pending = -1
+		}
+
+		hole := SkinsHole{Number: first.Number, Value: carried + 1}
+		carried = 0
+		tied := false
+		for i, c := range cards {
+			h := c.Hole(first.Number)
+			if h == nil || h.PickedUp() {
+				continue
+			}
+			score := h.Score(input.Scoring)
+			switch {
+			case hole.Winner == "" && !tied || score < hole.Score:
+				hole.Winner, hole.Score, tied = input.Players[i].ID, score, false
+			case score == hole.Score:
+				hole.Winner, tied = "", true
+			}
+		}
+
+		switch {
+		case hole.Winner == "" && carryover:
+			carried = hole.Value
+		case hole.Winner == "":
+			out.Unclaimed += hole.Value
+		case validate && hole.Score < first.Par:
+			pending = len(out.Holes)
+		default:
+			hole.Validated = true
+			out.Skins[hole.Winner] += hole.Value
+		}
+		out.Holes = append(out.Holes, hole)
+	}
+	if pending >= 0 {
+		// The last hole has no next hole to validate its skin on.
+		out.Holes[pending].Validated = true
+		out.Skins[out.Holes[pending].Winner] += out.Holes[pending].Value
+	}
+	out.Unclaimed += carried
+
+	if input.Pot != nil {
+		won := 0
+		for _, count := range out.Skins {
+			won += count
+		}
+		if won > 0 {
+			out.SkinValue = *input.Pot / float64(won)
+			out.Payouts = map[string]float64{}
+			for id, count := range out.Skins {
+				out.Payouts[id] = roundCent(out.SkinValue * float64(count))
+			}
+		}
+	}
+	return out, nil
+}
+
+// cardOf returns the card of the player with the ID.
+func cardOf(cards []*Card, id string) *Card {
+	for _, c := range cards {
+		if c.Player.ID == id {
+			return c
+		}
+	}
+	return nil
+}
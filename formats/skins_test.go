@@ -0,0 +1,119 @@
+package formats
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSkins(t *testing.T) {
+	boolPtr := func(v bool) *bool { return &v }
+	players := []Player{
+		{ID: "a", Holes: testHoles(4, 3, 5, 4, 4, 3)},
+		{ID: "b", Holes: testHoles(4, 4, 4, 3, 5, 4)},
+		{ID: "c", Holes: testHoles(5, 4, 4, 4, 4, 4)},
+	}
+
+	tests := []struct {
+		name          string
+		input         SkinsInput
+		wantSkins     map[string]int
+		wantUnclaimed int
+		wantValues    []int
+	}{
+		{
+			name:       "carryover",
+			input:      SkinsInput{Players: players, Tee: testTee(6), Scoring: ScoringGross},
+			wantSkins:  map[string]int{"a": 4, "b": 2},
+			wantValues: []int{1, 2, 1, 2, 1, 2},
+		},
+		{
+			name: "no carryover",
+			input: SkinsInput{Players: players, Tee: testTee(6), Scoring: ScoringGross,
+				Carryover: boolPtr(false)},
+			wantSkins:     map[string]int{"a": 2, "b": 1},
+			wantUnclaimed: 3,
+			wantValues:    []int{1, 1, 1, 1, 1, 1},
+		},
+		{
+			// The birdies on holes 2 and 4 are followed by bogeys, so only the birdie on the last hole is awarded.
+			name: "birdies must be validated",
+			input: SkinsInput{Players: players, Tee: testTee(6), Scoring: ScoringGross,
+				ValidateBirdies: boolPtr(true)},
+			wantSkins:  map[string]int{"a": 6},
+			wantValues: []int{1, 2, 3, 4, 5, 6},
+		},
+		{
+			// Player c receives a stroke on each hole, ties the first two holes and the last, and wins the rest.
+			name: "net",
+			input: SkinsInput{
+				Players: []Player{
+					players[0],
+					{ID: "c", PlayingHandicap: 6, Holes: players[2].Holes},
+				},
+				Tee:     testTee(6),
+				Scoring: ScoringNet,
+			},
+			wantSkins:     map[string]int{"c": 5},
+			wantUnclaimed: 1,
+			wantValues:    []int{1, 2, 3, 1, 1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Skins(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(result.Skins, tt.wantSkins) {
+				t.Errorf("skins = %v, want %v", result.Skins, tt.wantSkins)
+			}
+			if result.Unclaimed != tt.wantUnclaimed {
+				t.Errorf("unclaimed = %d, want %d", result.Unclaimed, tt.wantUnclaimed)
+			}
+			var values []int
+			for _, h := range result.Holes {
+				values = append(values, h.Value)
+			}
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("values = %v, want %v", values, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestSkinsPayouts(t *testing.T) {
+	pot := 100.0
+	result, err := Skins(SkinsInput{
+		Players: []Player{
+			{ID: "a", Holes: testHoles(3, 4, 4)},
+			{ID: "b", Holes: testHoles(4, 3, 3)},
+		},
+		Tee:     testTee(3),
+		Scoring: ScoringGross,
+		Pot:     &pot,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{"a": 33.33, "b": 66.67}
+	if !reflect.DeepEqual(result.Payouts, want) {
+		t.Errorf("payouts = %v, want %v", result.Payouts, want)
+	}
+}
+
+func TestSkinsCarriedPastLastHole(t *testing.T) {
+	result, err := Skins(SkinsInput{
+		Players: []Player{
+			{ID: "a", Holes: testHoles(3, 4)},
+			{ID: "b", Holes: testHoles(4, 4)},
+		},
+		Tee:     testTee(2),
+		Scoring: ScoringGross,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Skins["a"] != 1 || result.Unclaimed != 1 {
+		t.Errorf("skins = %v with %d unclaimed, want 1 for a and 1 unclaimed", result.Skins, result.Unclaimed)
+	}
+}
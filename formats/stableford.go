@@ -0,0 +1,96 @@
+package formats
+
+import (
+	"sort"
+
+	"github.com/C-Deck/ghin"
+)
+
+// PointTable awards Stableford points for a hole score relative to par, keyed by strokes over par. Scores better
+// than the lowest key earn the points of the lowest key and scores worse than the highest key earn the points of
+// the highest key.
+type PointTable map[int]int
+
+var (
+	// StandardPoints is the table of the Stableford system: 0 points for a double bogey or worse, 1 for a bogey,
+	// 2 for a par and one more point for each stroke under par.
+	StandardPoints = PointTable{2: 0, 1: 1, 0: 2, -1: 3, -2: 4, -3: 5, -4: 6}
+	// ModifiedPoints is the table of the modified Stableford system played on the PGA Tour: -3 points for a double
+	// bogey or worse, -1 for a bogey, 0 for a par, 2 for a birdie, 5 for an eagle and 8 for an albatross.
+	ModifiedPoints = PointTable{2: -3, 1: -1, 0: 0, -1: 2, -2: 5, -3: 8}
+)
+
+// Points returns the points earned by a score of toPar strokes over par.
+func (t PointTable) Points(toPar int) int {
+	if points, ok := t[toPar]; ok {
+		return points
+	}
+	keys := make([]int, 0, len(t))
+	for k := range t {
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return 0
+	}
+	sort.Ints(keys)
+	if toPar < keys[0] {
+		return t[keys[0]]
+	}
+	if toPar > keys[len(keys)-1] {
+		return t[keys[len(keys)-1]]
+	}
+	// Scores between two keys of a sparse table earn the points of the next worse key.
+	i := sort.SearchInts(keys, toPar)
+	return t[keys[i]]
+}
+
+// worst returns the points of the highest key of the table, earned by the worst scores.
+func (t PointTable) worst() int {
+	var key, points int
+	first := true
+	for k, p := range t {
+		if first || k > key {
+			key, points, first = k, p, false
+		}
+	}
+	return points
+}
+
+// StablefordHole is the result of a player on a hole.
+type StablefordHole struct {
+	Hole
+	// ToPar is the gross or net score of the hole relative to par. It is zero when the player picked up.
+	ToPar  int
+	Points int
+}
+
+// StablefordResult is the result of a player over a round.
+type StablefordResult struct {
+	Player  Player
+	Scoring Scoring
+	Holes   []StablefordHole
+	Points  int
+}
+
+// Stableford scores the round of the player using the point table. Net scoring deducts the strokes the player
+// receives on each hole, see NewCard. Holes the player picked up earn the points of the worst score of the table.
+func Stableford(player Player, tee ghin.TeeSetDetails, scoring Scoring, table PointTable) (*StablefordResult, error) {
+	card, err := NewCard(player, tee)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &StablefordResult{Player: player, Scoring: scoring}
+	for _, h := range card.Holes {
+		hole := StablefordHole{Hole: h}
+		if h.PickedUp() {
+			hole.Points = table.worst()
+		} else {
+			hole.ToPar = h.Score(scoring) - h.Par
+			hole.Points = table.Points(hole.ToPar)
+		}
+		out.Holes = append(out.Holes, hole)
+		out.Points += hole.Points
+	}
+	return out, nil
+}
@@ -0,0 +1,216 @@
+package formats
+
+import (
+	"math"
+	"sort"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+// ScrambleAllowances are the percentages of the playing handicaps of its players that make up the handicap of a
+// scramble team, keyed by team size and ordered from the lowest to the highest handicap. The two and four player
+// allowances are those recommended by the Rules of Handicapping. The Rules make no recommendation for three player
+// scrambles, so their allowances are the 30, 20 and 10 percent commonly used by committees.
+var ScrambleAllowances = map[int][]float64{
+	2: {35, 15},
+	3: {30, 20, 10},
+	4: {25, 20, 15, 10},
+}
+
+type TeamInput struct {
+	// Format is one of FormatBestBall, FormatScramble, FormatShamble or FormatAggregate.
+	Format Format
+	// Teams have 2 to 4 players. The hole scores of a scramble team are the hole scores of its first player.
+	Teams []Side
+	// Tee is the tee set the teams played.
+	Tee     ghin.TeeSetDetails
+	Scoring Scoring
+
+	/* Balls is the number of scores of a team that count on each hole of a best ball or shamble.
+	 * Default: 1
+	 */
+	Balls *int
+	/* Allowance is the percentage of their playing handicap each player receives in a best ball, shamble or
+	 * aggregate. The Rules of Handicapping recommend 85 for a four-ball stroke play.
+	 * Default: 100
+	 */
+	Allowance *float64
+	/* ScrambleAllowances are the percentages of the playing handicaps of the players of a scramble team, ordered
+	 * from the lowest to the highest handicap.
+	 * Default: The ScrambleAllowances of the team size
+	 */
+	ScrambleAllowances []float64
+}
+
+// TeamHole is the score of a team on a hole.
+type TeamHole struct {
+	Number int
+	Par    int
+	// Score is the sum of the gross or net scores that counted on the hole.
+	Score int
+	// Counted are the IDs of the players whose scores counted on the hole.
+	Counted []string
+}
+
+// TeamResult is the result of a team on a leaderboard.
+type TeamResult struct {
+	Team Side
+	// Handicap is the handicap of a scramble team, zero for other formats.
+	Handicap int
+	Holes    []TeamHole
+	Total    int
+	// ToPar is Total relative to the par of the scores counted.
+	ToPar int
+	// Position is the rank of the team on the leaderboard, shared by teams with the same total.
+	Position int
+	Tied     bool
+}
+
+// TeamLeaderboard scores the teams in the team format and ranks them from the lowest to the highest total. Holes are
+// played in the order of the hole scores of the first player of each team. Every player of an aggregate, and at least
+// Balls players of a best ball or shamble, must hole out on each hole.
+func TeamLeaderboard(input TeamInput) ([]TeamResult, error) {
+	balls := 1
+	if input.Balls != nil {
+		balls = *input.Balls
+	}
+	allowance := 100.0
+	if input.Allowance != nil {
+		allowance = *input.Allowance
+	}
+
+	var out []TeamResult
+	for _, team := range input.Teams {
+		if len(team.Players) < 2 || len(team.Players) > 4 {
+			return nil, errors.Errorf("team %s must have 2 to 4 players, got %d", team.DisplayName(), len(team.Players))
+		}
+
+		var result *TeamResult
+		var err error
+		switch input.Format {
+		case FormatScramble:
+			result, err = scrambleResult(team, input)
+		case FormatBestBall, FormatShamble:
+			result, err = bestBallsResult(team, input, balls, allowance)
+		case FormatAggregate:
+			result, err = bestBallsResult(team, input, len(team.Players), allowance)
+		default:
+			return nil, errors.Errorf("%s is not a team format", input.Format)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem scoring team %s", team.DisplayName())
+		}
+		out = append(out, *result)
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].Total < out[j].Total
+	})
+	for i := range out {
+		out[i].Position = i + 1
+		if i > 0 && out[i].Total == out[i-1].Total {
+			out[i].Position = out[i-1].Position
+			out[i].Tied, out[i-1].Tied = true, true
+		}
+	}
+	return out, nil
+}
+
+// ScrambleHandicap calculates the handicap of a scramble team from the playing handicaps of its players and the
+// allowances, ordered from the lowest to the highest handicap.
+func ScrambleHandicap(playingHandicaps []int, allowances []float64) (int, error) {
+	if len(playingHandicaps) != len(allowances) {
+		return 0, errors.Errorf("%d allowances are required for a team of %d players, got %d",
+			len(playingHandicaps), len(playingHandicaps), len(allowances))
+	}
+	sorted := append([]int(nil), playingHandicaps...)
+	sort.Ints(sorted)
+	var total float64
+	for i, h := range sorted {
+		total += float64(h) * allowances[i] / 100
+	}
+	return int(math.Round(total)), nil
+}
+
+func scrambleResult(team Side, input TeamInput) (*TeamResult, error) {
+	allowances := input.ScrambleAllowances
+	if allowances == nil {
+		allowances = ScrambleAllowances[len(team.Players)]
+	}
+	if allowances == nil {
+		return nil, errors.Errorf("no scramble allowances for a team of %d players", len(team.Players))
+	}
+	handicaps := make([]int, len(team.Players))
+	for i, p := range team.Players {
+		handicaps[i] = p.PlayingHandicap
+	}
+	handicap, err := ScrambleHandicap(handicaps, allowances)
+	if err != nil {
+		return nil, err
+	}
+
+	captain := team.Players[0]
+	card, err := NewCard(Player{ID: captain.ID, Name: team.DisplayName(), PlayingHandicap: handicap,
+		Holes: captain.Holes, TeeSetSide: captain.TeeSetSide}, input.Tee)
+	if err != nil {
+		return nil, err
+	}
+	out := &TeamResult{Team: team, Handicap: handicap}
+	for _, h := range card.Holes {
+		if h.PickedUp() {
+			return nil, errors.Errorf("team has no score for hole %d", h.Number)
+		}
+		out.add(TeamHole{Number: h.Number, Par: h.Par, Score: h.Score(input.Scoring), Counted: []string{captain.ID}}, 1)
+	}
+	return out, nil
+}
+
+func bestBallsResult(team Side, input TeamInput, balls int, allowance float64) (*TeamResult, error) {
+	if balls < 1 || balls > len(team.Players) {
+		return nil, errors.Errorf("between 1 and %d balls can count, got %d", len(team.Players), balls)
+	}
+	cards := make([]*Card, len(team.Players))
+	for i, p := range team.Players {
+		p.PlayingHandicap = int(math.Round(float64(p.PlayingHandicap) * allowance / 100))
+		card, err := NewCard(p, input.Tee)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem allocating strokes of player %s", p.ID)
+		}
+		cards[i] = card
+	}
+
+	out := &TeamResult{Team: team}
+	for _, first := range cards[0].Holes {
+		type ball struct {
+			player string
+			score  int
+		}
+		var scores []ball
+		for _, c := range cards {
+			if h := c.Hole(first.Number); h != nil && !h.PickedUp() {
+				scores = append(scores, ball{player: c.Player.ID, score: h.Score(input.Scoring)})
+			}
+		}
+		if len(scores) < balls {
+			return nil, errors.Errorf("%d scores are required on hole %d, got %d", balls, first.Number, len(scores))
+		}
+		sort.SliceStable(scores, func(i, j int) bool {
+			return scores[i].score < scores[j].score
+		})
+
+		hole := TeamHole{Number: first.Number, Par: first.Par}
+		for _, b := range scores[:balls] {
+			hole.Score += b.score
+			hole.Counted = append(hole.Counted, b.player)
+		}
+		out.add(hole, balls)
+	}
+	return out, nil
+}
+
+func (r *TeamResult) add(hole TeamHole, balls int) {
+	r.Holes = append(r.Holes, hole)
+	r.Total += hole.Score
+	r.ToPar += hole.Score - hole.Par*balls
+}
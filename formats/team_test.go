@@ -0,0 +1,191 @@
+package formats
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScrambleHandicap(t *testing.T) {
+	tests := []struct {
+		name       string
+		handicaps  []int
+		allowances []float64
+		want       int
+		wantErr    bool
+	}{
+		{name: "two players", handicaps: []int{10, 20}, allowances: ScrambleAllowances[2], want: 7},
+		{name: "lowest handicap takes the first allowance", handicaps: []int{20, 10}, allowances: ScrambleAllowances[2],
+			want: 7},
+		{name: "three players", handicaps: []int{20, 5, 10}, allowances: ScrambleAllowances[3], want: 6},
+		{name: "four players", handicaps: []int{16, 4, 12, 8}, allowances: ScrambleAllowances[4], want: 6},
+		{name: "plus handicap", handicaps: []int{-2, 10}, allowances: ScrambleAllowances[2], want: 1},
+		{name: "missing allowance", handicaps: []int{10, 20, 30}, allowances: ScrambleAllowances[2], wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScrambleHandicap(tt.handicaps, tt.allowances)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ScrambleHandicap(%v, %v) error = %v, want error %t", tt.handicaps, tt.allowances, err,
+					tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ScrambleHandicap(%v, %v) = %d, want %d", tt.handicaps, tt.allowances, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTeamLeaderboard(t *testing.T) {
+	two := 2
+	pars := Side{Name: "pars", Players: []Player{
+		{ID: "p1", Holes: testHoles(4, 4, 4, 4)},
+		{ID: "p2", Holes: testHoles(4, 4, 4, 4)},
+	}}
+	birdies := Side{Name: "birdies", Players: []Player{
+		{ID: "b1", Holes: testHoles(4, 3, 5, 4)},
+		{ID: "b2", Holes: testHoles(3, 4, 4, 0)},
+	}}
+	evens := Side{Name: "evens", Players: []Player{
+		{ID: "e1", Holes: testHoles(4, 4, 4, 4)},
+		{ID: "e2", Holes: testHoles(5, 5, 5, 5)},
+	}}
+
+	tests := []struct {
+		name      string
+		input     TeamInput
+		wantTeams []string
+		wantTotal []int
+		wantToPar []int
+		wantPos   []int
+	}{
+		{
+			name: "best ball",
+			input: TeamInput{Format: FormatBestBall, Teams: []Side{pars, birdies, evens}, Tee: testTee(4),
+				Scoring: ScoringGross},
+			wantTeams: []string{"birdies", "pars", "evens"},
+			wantTotal: []int{14, 16, 16},
+			wantToPar: []int{-2, 0, 0},
+			wantPos:   []int{1, 2, 2},
+		},
+		{
+			name: "two balls count",
+			input: TeamInput{Format: FormatBestBall, Teams: []Side{pars, evens}, Tee: testTee(4),
+				Scoring: ScoringGross, Balls: &two},
+			wantTeams: []string{"pars", "evens"},
+			wantTotal: []int{32, 36},
+			wantToPar: []int{0, 4},
+			wantPos:   []int{1, 2},
+		},
+		{
+			name: "aggregate",
+			input: TeamInput{Format: FormatAggregate, Teams: []Side{evens, pars}, Tee: testTee(4),
+				Scoring: ScoringGross},
+			wantTeams: []string{"pars", "evens"},
+			wantTotal: []int{32, 36},
+			wantToPar: []int{0, 4},
+			wantPos:   []int{1, 2},
+		},
+		{
+			// The team handicap of 7 gives two strokes on the three hardest holes and one on the last.
+			name: "net scramble",
+			input: TeamInput{
+				Format: FormatScramble,
+				Teams: []Side{{Name: "scramble", Players: []Player{
+					{ID: "s1", PlayingHandicap: 10, Holes: testHoles(4, 4, 4, 4)},
+					{ID: "s2", PlayingHandicap: 20},
+				}}},
+				Tee:     testTee(4),
+				Scoring: ScoringNet,
+			},
+			wantTeams: []string{"scramble"},
+			wantTotal: []int{9},
+			wantToPar: []int{-7},
+			wantPos:   []int{1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := TeamLeaderboard(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var teams []string
+			var totals, toPars, positions []int
+			for _, r := range results {
+				teams = append(teams, r.Team.Name)
+				totals = append(totals, r.Total)
+				toPars = append(toPars, r.ToPar)
+				positions = append(positions, r.Position)
+			}
+			if !reflect.DeepEqual(teams, tt.wantTeams) || !reflect.DeepEqual(totals, tt.wantTotal) ||
+				!reflect.DeepEqual(toPars, tt.wantToPar) || !reflect.DeepEqual(positions, tt.wantPos) {
+				t.Errorf("got teams %v totals %v to par %v positions %v, want %v %v %v %v",
+					teams, totals, toPars, positions, tt.wantTeams, tt.wantTotal, tt.wantToPar, tt.wantPos)
+			}
+		})
+	}
+}
+
+func TestTeamLeaderboardCountedScores(t *testing.T) {
+	results, err := TeamLeaderboard(TeamInput{
+		Format: FormatBestBall,
+		Teams: []Side{{Players: []Player{
+			{ID: "a", Holes: testHoles(4, 3)},
+			{ID: "b", Holes: testHoles(3, 4)},
+		}}},
+		Tee:     testTee(2),
+		Scoring: ScoringGross,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TeamHole{
+		{Number: 1, Par: 4, Score: 3, Counted: []string{"b"}},
+		{Number: 2, Par: 4, Score: 3, Counted: []string{"a"}},
+	}
+	if !reflect.DeepEqual(results[0].Holes, want) {
+		t.Errorf("holes = %+v, want %+v", results[0].Holes, want)
+	}
+}
+
+func TestTeamLeaderboardErrors(t *testing.T) {
+	two := 2
+	pickedUp := Side{Players: []Player{
+		{ID: "a", Holes: testHoles(4, 0)},
+		{ID: "b", Holes: testHoles(4, 4)},
+	}}
+	tests := []struct {
+		name  string
+		input TeamInput
+	}{
+		{name: "too few players", input: TeamInput{Format: FormatBestBall,
+			Teams: []Side{{Players: []Player{{ID: "a", Holes: testHoles(4, 4)}}}}, Tee: testTee(2)}},
+		{name: "not a team format", input: TeamInput{Format: FormatSkins, Teams: []Side{pickedUp}, Tee: testTee(2)}},
+		{name: "too few scores for the balls", input: TeamInput{Format: FormatBestBall, Teams: []Side{pickedUp},
+			Tee: testTee(2), Balls: &two}},
+		{name: "aggregate player picked up", input: TeamInput{Format: FormatAggregate, Teams: []Side{pickedUp},
+			Tee: testTee(2)}},
+		{name: "scramble picked up", input: TeamInput{Format: FormatScramble, Teams: []Side{pickedUp},
+			Tee: testTee(2)}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := TeamLeaderboard(tt.input); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestFormatPostable(t *testing.T) {
+	for _, f := range []Format{FormatStableford, FormatMatchPlay, FormatBestBall, FormatAggregate} {
+		if !f.Postable() {
+			t.Errorf("%s should be postable", f)
+		}
+	}
+	for _, f := range []Format{FormatScramble, FormatShamble} {
+		if f.Postable() {
+			t.Errorf("%s should not be postable", f)
+		}
+	}
+}
@@ -0,0 +1,145 @@
+package handicap
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SoftCapThreshold is the increase over the Low Handicap Index above which further increases are halved.
+	SoftCapThreshold = 3.0
+
+	// HardCapThreshold is the largest increase over the Low Handicap Index that is allowed.
+	HardCapThreshold = 5.0
+
+	// lowIndexPeriod is how far back revisions are considered for the Low Handicap Index.
+	lowIndexPeriod = 365 * 24 * time.Hour
+)
+
+// Cap represents which cap limited a Handicap Index.
+type Cap string
+
+const (
+	CapNone Cap = "None"
+	CapSoft Cap = "Soft"
+	CapHard Cap = "Hard"
+)
+
+// Revision is a Handicap Index issued on a given date.
+type Revision struct {
+	Date  time.Time
+	Index float64
+}
+
+type CapResult struct {
+	// LowIndex is the Low Handicap Index the caps were evaluated against.
+	LowIndex float64
+	// Uncapped is the Handicap Index calculated from the scoring record.
+	Uncapped float64
+	// Capped is the Handicap Index once the caps have been applied.
+	Capped float64
+	// SoftCapReduction is the amount removed from the index by the soft cap.
+	SoftCapReduction float64
+	// HardCapReduction is the amount removed from the index by the hard cap, after the soft cap was applied.
+	HardCapReduction float64
+	// Cap is the most restrictive cap that was applied.
+	Cap Cap
+}
+
+// LowIndex returns the lowest Handicap Index issued in the 365 days preceding asOf, which should be the date the
+// most recent score in the scoring record was played. The second value is false when no revision falls in that
+// period.
+func LowIndex(revisions []Revision, asOf time.Time) (float64, bool) {
+	var low float64
+	var found bool
+	start := asOf.Add(-lowIndexPeriod)
+	for _, r := range revisions {
+		if r.Date.Before(start) || !r.Date.Before(asOf) {
+			continue
+		}
+		if !found || r.Index < low {
+			low = r.Index
+			found = true
+		}
+	}
+	return low, found
+}
+
+// RevisionsFromHistory converts the handicap history retrieved from GHIN into revisions.
+func RevisionsFromHistory(history []ghin.HandicapRevision) ([]Revision, error) {
+	out := make([]Revision, len(history))
+	for i, h := range history {
+		date, err := h.Date()
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem parsing date of revision %d", h.Id)
+		}
+		out[i] = Revision{Date: date, Index: h.Value}
+	}
+	return out, nil
+}
+
+// ApplyCaps limits the increase of index over the Low Handicap Index. Increases above SoftCapThreshold are halved,
+// and the total increase can never exceed HardCapThreshold.
+func ApplyCaps(index, lowIndex float64) CapResult {
+	out := CapResult{LowIndex: lowIndex, Uncapped: index, Capped: index, Cap: CapNone}
+
+	if increase := roundTenth(index - lowIndex); increase > SoftCapThreshold {
+		out.Capped = roundTenth(lowIndex + SoftCapThreshold + (increase-SoftCapThreshold)/2)
+		out.SoftCapReduction = roundTenth(index - out.Capped)
+		out.Cap = CapSoft
+	}
+	if increase := roundTenth(out.Capped - lowIndex); increase > HardCapThreshold {
+		hardCapped := roundTenth(lowIndex + HardCapThreshold)
+		out.HardCapReduction = roundTenth(out.Capped - hardCapped)
+		out.Capped = hardCapped
+		out.Cap = CapHard
+	}
+
+	return out
+}
+
+// GolferCap returns the cap GHIN reports as active for the golfer.
+func GolferCap(golfer ghin.Golfer) (Cap, error) {
+	hard, err := parseCapFlag(golfer.HardCap)
+	if err != nil {
+		return "", errors.Wrapf(err, "problem parsing hard cap %q", golfer.HardCap)
+	}
+	soft, err := parseCapFlag(golfer.SoftCap)
+	if err != nil {
+		return "", errors.Wrapf(err, "problem parsing soft cap %q", golfer.SoftCap)
+	}
+
+	switch {
+	case hard:
+		return CapHard, nil
+	case soft:
+		return CapSoft, nil
+	}
+	return CapNone, nil
+}
+
+// ParseIndex parses a Handicap Index as displayed by GHIN, such as the Display and LowHiDisplay fields of a Golfer.
+// Plus handicaps ("+1.2") are returned as negative values.
+func ParseIndex(display string) (float64, error) {
+	display = strings.TrimSpace(display)
+	plus := strings.HasPrefix(display, "+")
+	index, err := strconv.ParseFloat(strings.TrimPrefix(display, "+"), 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "problem parsing handicap index %q", display)
+	}
+	if plus {
+		index = -index
+	}
+	return index, nil
+}
+
+func parseCapFlag(flag string) (bool, error) {
+	if flag == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(flag)
+}
@@ -0,0 +1,143 @@
+package handicap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/C-Deck/ghin"
+)
+
+func TestApplyCaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		index    float64
+		lowIndex float64
+		want     CapResult
+	}{
+		{
+			name:     "below the soft cap",
+			index:    12.0,
+			lowIndex: 10.0,
+			want:     CapResult{LowIndex: 10.0, Uncapped: 12.0, Capped: 12.0, Cap: CapNone},
+		},
+		{
+			name:     "at the soft cap",
+			index:    13.0,
+			lowIndex: 10.0,
+			want:     CapResult{LowIndex: 10.0, Uncapped: 13.0, Capped: 13.0, Cap: CapNone},
+		},
+		{
+			name:     "soft cap halves the increase above 3",
+			index:    14.0,
+			lowIndex: 10.0,
+			want:     CapResult{LowIndex: 10.0, Uncapped: 14.0, Capped: 13.5, SoftCapReduction: 0.5, Cap: CapSoft},
+		},
+		{
+			name:     "hard cap limits the increase to 5",
+			index:    20.0,
+			lowIndex: 10.0,
+			want: CapResult{LowIndex: 10.0, Uncapped: 20.0, Capped: 15.0, SoftCapReduction: 3.5,
+				HardCapReduction: 1.5, Cap: CapHard},
+		},
+		{
+			name:     "decrease",
+			index:    8.0,
+			lowIndex: 10.0,
+			want:     CapResult{LowIndex: 10.0, Uncapped: 8.0, Capped: 8.0, Cap: CapNone},
+		},
+		{
+			name:     "plus low index",
+			index:    4.0,
+			lowIndex: -1.0,
+			want:     CapResult{LowIndex: -1.0, Uncapped: 4.0, Capped: 3.0, SoftCapReduction: 1.0, Cap: CapSoft},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ApplyCaps(tt.index, tt.lowIndex); got != tt.want {
+				t.Errorf("ApplyCaps(%.1f, %.1f) = %+v, want %+v", tt.index, tt.lowIndex, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLowIndex(t *testing.T) {
+	asOf := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	revisions := []Revision{
+		{Date: asOf.AddDate(-2, 0, 0), Index: 4.0},
+		{Date: asOf.AddDate(0, -6, 0), Index: 9.5},
+		{Date: asOf.AddDate(0, -1, 0), Index: 8.7},
+		{Date: asOf, Index: 6.0},
+	}
+	low, ok := LowIndex(revisions, asOf)
+	if !ok || low != 8.7 {
+		t.Errorf("LowIndex = %.1f, %t, want 8.7 from the revisions of the last year before the date", low, ok)
+	}
+
+	if _, ok := LowIndex(revisions[:1], asOf); ok {
+		t.Error("expected no low index without a revision in the last year")
+	}
+}
+
+func TestGolferCap(t *testing.T) {
+	tests := []struct {
+		name    string
+		golfer  ghin.Golfer
+		want    Cap
+		wantErr bool
+	}{
+		{name: "no cap", golfer: ghin.Golfer{}, want: CapNone},
+		{name: "soft cap", golfer: ghin.Golfer{SoftCap: "true", HardCap: "false"}, want: CapSoft},
+		{name: "hard cap wins", golfer: ghin.Golfer{SoftCap: "true", HardCap: "true"}, want: CapHard},
+		{name: "invalid flag", golfer: ghin.Golfer{SoftCap: "maybe"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GolferCap(tt.golfer)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GolferCap error = %v, want error %t", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("GolferCap = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseIndex(t *testing.T) {
+	tests := []struct {
+		display string
+		want    float64
+		wantErr bool
+	}{
+		{display: "12.4", want: 12.4},
+		{display: " 0.0 ", want: 0},
+		{display: "+1.2", want: -1.2},
+		{display: "NH", wantErr: true},
+		{display: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := ParseIndex(tt.display)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseIndex(%q) error = %v, want error %t", tt.display, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseIndex(%q) = %.1f, want %.1f", tt.display, got, tt.want)
+		}
+	}
+}
+
+func TestRevisionsFromHistory(t *testing.T) {
+	revisions, err := RevisionsFromHistory([]ghin.HandicapRevision{{Id: 1, RevDate: "2024-06-01", Value: 12.3}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Revision{Date: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Index: 12.3}
+	if len(revisions) != 1 || revisions[0] != want {
+		t.Errorf("RevisionsFromHistory = %+v, want %+v", revisions, want)
+	}
+
+	if _, err := RevisionsFromHistory([]ghin.HandicapRevision{{Id: 1, RevDate: "not a date"}}); err == nil {
+		t.Error("expected an error for a revision without a valid date")
+	}
+}
@@ -0,0 +1,29 @@
+package handicap
+
+import (
+	"fmt"
+)
+
+type InsufficientScoresError struct {
+	Count int
+}
+
+func NewInsufficientScoresError(count int) error {
+	return &InsufficientScoresError{Count: count}
+}
+
+func (e InsufficientScoresError) Error() string {
+	return fmt.Sprintf("at least 3 scores are required to calculate a handicap index, got %d", e.Count)
+}
+
+type TargetUnreachableError struct {
+	Target float64
+}
+
+func NewTargetUnreachableError(target float64) error {
+	return &TargetUnreachableError{Target: target}
+}
+
+func (e TargetUnreachableError) Error() string {
+	return fmt.Sprintf("no score on this tee set would result in a handicap index of %.1f", e.Target)
+}
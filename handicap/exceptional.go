@@ -0,0 +1,99 @@
+package handicap
+
+import (
+	"github.com/C-Deck/ghin"
+)
+
+const (
+	// ExceptionalOneStrokeThreshold is how far below the Handicap Index a differential must be to trigger a one
+	// stroke exceptional score reduction.
+	ExceptionalOneStrokeThreshold = 7.0
+
+	// ExceptionalTwoStrokesThreshold is how far below the Handicap Index a differential must be to trigger a two
+	// stroke exceptional score reduction.
+	ExceptionalTwoStrokesThreshold = 10.0
+)
+
+type ExceptionalScore struct {
+	// Differential is the differential of the posted score.
+	Differential float64
+	// IndexAtPlay is the Handicap Index of the golfer when the score was played.
+	IndexAtPlay float64
+	// Reduction is the adjustment (-1 or -2) applied to each differential in the scoring record, or 0 when the score
+	// is not exceptional.
+	Reduction int
+	// IndexWithoutReduction is the Handicap Index after posting the score if no reduction were applied.
+	IndexWithoutReduction float64
+	// Index is the Handicap Index once the exceptional score reduction has been applied.
+	Index float64
+}
+
+// Exceptional reports whether a posted score is exceptional and how it changes the Handicap Index. The record is
+// the scoring record before the score was posted and indexAtPlay is the golfer's Handicap Index on the day the
+// score was played.
+func Exceptional(record []ghin.Score, posted ghin.Score, indexAtPlay float64) (*ExceptionalScore, error) {
+	scores := append([]ghin.Score(nil), record...)
+	SortMostRecentFirst(scores)
+	if len(scores) >= ScoringRecordSize {
+		scores = scores[:ScoringRecordSize-1]
+	}
+	posted.ESR = nil
+	scores = append([]ghin.Score{posted}, scores...)
+
+	out := &ExceptionalScore{
+		Differential: posted.Differential,
+		IndexAtPlay:  indexAtPlay,
+		Reduction:    ExceptionalReduction(posted.Differential, indexAtPlay),
+	}
+
+	var err error
+	differentials := AdjustedDifferentials(scores)
+	out.IndexWithoutReduction, err = Index(differentials)
+	if err != nil {
+		return nil, err
+	}
+	out.Index, err = Index(ApplyExceptionalReduction(differentials, out.Reduction))
+	if err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// ExceptionalReduction returns the adjustment (-1 or -2) to apply to each differential of the scoring record when a
+// differential is exceptionally low compared to the Handicap Index at the time of play. Zero is returned for
+// scores that are not exceptional.
+func ExceptionalReduction(differential, indexAtPlay float64) int {
+	switch below := roundTenth(indexAtPlay - differential); {
+	case below >= ExceptionalTwoStrokesThreshold:
+		return -2
+	case below >= ExceptionalOneStrokeThreshold:
+		return -1
+	}
+	return 0
+}
+
+// ApplyExceptionalReduction adds the reduction to each of the ScoringRecordSize most recent differentials.
+// The differentials should be ordered from most to least recent.
+func ApplyExceptionalReduction(differentials []float64, reduction int) []float64 {
+	out := append([]float64(nil), differentials...)
+	for i := 0; i < len(out) && i < ScoringRecordSize; i++ {
+		out[i] = roundTenth(out[i] + float64(reduction))
+	}
+	return out
+}
+
+// AdjustedDifferentials returns the differentials of scores ordered from most to least recent once the exceptional
+// score reductions recorded in their ESR field have been applied. A reduction applies to the score that triggered
+// it and every score played before it.
+func AdjustedDifferentials(scores []ghin.Score) []float64 {
+	out := Differentials(scores)
+	var reduction int
+	for i, s := range scores {
+		if s.ESR != nil {
+			reduction += *s.ESR
+		}
+		out[i] = roundTenth(out[i] + float64(reduction))
+	}
+	return out
+}
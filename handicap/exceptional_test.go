@@ -0,0 +1,67 @@
+package handicap
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExceptionalReduction(t *testing.T) {
+	tests := []struct {
+		name         string
+		differential float64
+		indexAtPlay  float64
+		want         int
+	}{
+		{name: "not exceptional", differential: 10.1, indexAtPlay: 17.0, want: 0},
+		{name: "7 strokes below", differential: 10.0, indexAtPlay: 17.0, want: -1},
+		{name: "9.9 strokes below", differential: 5.1, indexAtPlay: 15.0, want: -1},
+		{name: "10 strokes below", differential: 5.0, indexAtPlay: 15.0, want: -2},
+		{name: "plus index", differential: -9.0, indexAtPlay: -1.5, want: -1},
+		{name: "above the index", differential: 20.0, indexAtPlay: 15.0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExceptionalReduction(tt.differential, tt.indexAtPlay); got != tt.want {
+				t.Errorf("ExceptionalReduction(%.1f, %.1f) = %d, want %d", tt.differential, tt.indexAtPlay, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyExceptionalReduction(t *testing.T) {
+	differentials := append(constant(10, ScoringRecordSize), 12)
+	got := ApplyExceptionalReduction(differentials, -2)
+	want := append(constant(8, ScoringRecordSize), 12)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ApplyExceptionalReduction = %v, want %v", got, want)
+	}
+	if differentials[0] != 10 {
+		t.Error("ApplyExceptionalReduction changed the differentials it was given")
+	}
+}
+
+func TestAdjustedDifferentials(t *testing.T) {
+	oneStroke, twoStrokes := -1, -2
+	scores := testScores(5, 12, 3, 11, 10)
+	scores[0].ESR = &oneStroke
+	scores[2].ESR = &twoStrokes
+
+	// Each reduction applies to the score that triggered it and every older score.
+	want := []float64{4, 11, 0, 8, 7}
+	if got := AdjustedDifferentials(scores); !reflect.DeepEqual(got, want) {
+		t.Errorf("AdjustedDifferentials = %v, want %v", got, want)
+	}
+}
+
+func TestExceptional(t *testing.T) {
+	posted := testScores(0)[0]
+	posted.PlayedAt = "2025-01-01"
+	result, err := Exceptional(testScores(constant(10, 20)...), posted, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ExceptionalScore{Differential: 0, IndexAtPlay: 10, Reduction: -2, IndexWithoutReduction: 8.8, Index: 6.8}
+	if *result != want {
+		t.Errorf("Exceptional = %+v, want %+v", *result, want)
+	}
+}
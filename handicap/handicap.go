@@ -0,0 +1,120 @@
+// Package handicap implements World Handicap System calculations on top of the
+// score records returned by the GHIN API.
+package handicap
+
+import (
+	"math"
+	"sort"
+
+	"github.com/C-Deck/ghin"
+)
+
+const (
+	// ScoringRecordSize is the number of most recent scores that make up a scoring record.
+	ScoringRecordSize = 20
+
+	// MaxHandicapIndex is the highest Handicap Index that can be issued.
+	MaxHandicapIndex = 54.0
+
+	// StandardSlopeRating is the slope rating of a course of standard playing difficulty.
+	StandardSlopeRating = ghin.StandardSlopeRating
+)
+
+// indexCalculation describes how many differentials are averaged for a scoring record of a given size and the
+// adjustment that is applied to the average.
+type indexCalculation struct {
+	used       int
+	adjustment float64
+}
+
+// indexCalculations is indexed by the number of differentials in the scoring record.
+var indexCalculations = [ScoringRecordSize + 1]indexCalculation{
+	3:  {used: 1, adjustment: -2.0},
+	4:  {used: 1, adjustment: -1.0},
+	5:  {used: 1},
+	6:  {used: 2, adjustment: -1.0},
+	7:  {used: 2},
+	8:  {used: 2},
+	9:  {used: 3},
+	10: {used: 3},
+	11: {used: 3},
+	12: {used: 4},
+	13: {used: 4},
+	14: {used: 4},
+	15: {used: 5},
+	16: {used: 5},
+	17: {used: 6},
+	18: {used: 6},
+	19: {used: 7},
+	20: {used: 8},
+}
+
+// ScoreDifferential calculates the differential of a round from its adjusted gross score, the rating of the tee
+// set played and the playing conditions calculation of the day. It is ghin.ScoreDifferential.
+func ScoreDifferential(adjustedGrossScore int, courseRating float64, slopeRating int, pcc int) float64 {
+	return ghin.ScoreDifferential(adjustedGrossScore, courseRating, slopeRating, pcc)
+}
+
+// Index calculates a Handicap Index from the differentials of a scoring record. Only the ScoringRecordSize first
+// differentials are considered, so they should be ordered from most to least recent. An error is returned when
+// fewer than three differentials are provided.
+func Index(differentials []float64) (float64, error) {
+	if len(differentials) > ScoringRecordSize {
+		differentials = differentials[:ScoringRecordSize]
+	}
+	calc := indexCalculations[len(differentials)]
+	if calc.used == 0 {
+		return 0, NewInsufficientScoresError(len(differentials))
+	}
+
+	lowest := append([]float64(nil), differentials...)
+	sort.Float64s(lowest)
+	var total float64
+	for _, d := range lowest[:calc.used] {
+		total += d
+	}
+
+	index := roundTenth(total/float64(calc.used) + calc.adjustment)
+	return math.Min(index, MaxHandicapIndex), nil
+}
+
+// CourseHandicap calculates the number of strokes a golfer with the given Handicap Index receives on a tee set.
+func CourseHandicap(index float64, courseRating float64, slopeRating int, par int) int {
+	return int(math.Round(unroundedCourseHandicap(index, courseRating, slopeRating, par)))
+}
+
+// PlayingHandicap calculates the number of strokes a golfer receives in a format played with a handicap allowance,
+// a percentage of their course handicap. The allowance is applied to the unrounded course handicap, which is only
+// rounded once.
+func PlayingHandicap(index float64, courseRating float64, slopeRating int, par int, allowance float64) int {
+	return int(math.Round(unroundedCourseHandicap(index, courseRating, slopeRating, par) * allowance / 100))
+}
+
+func unroundedCourseHandicap(index float64, courseRating float64, slopeRating int, par int) float64 {
+	return index*float64(slopeRating)/StandardSlopeRating + courseRating - float64(par)
+}
+
+// Differentials returns the differentials of the scores in the order they were given.
+func Differentials(scores []ghin.Score) []float64 {
+	out := make([]float64, len(scores))
+	for i, s := range scores {
+		out[i] = s.Differential
+	}
+	return out
+}
+
+// SortMostRecentFirst orders scores from most to least recently played. Scores played on the same day are ordered
+// by their score day order.
+func SortMostRecentFirst(scores []ghin.Score) {
+	sort.SliceStable(scores, func(i, j int) bool {
+		if scores[i].PlayedAt != scores[j].PlayedAt {
+			return scores[i].PlayedAt > scores[j].PlayedAt
+		}
+		return scores[i].ScoreDayOrder > scores[j].ScoreDayOrder
+	})
+}
+
+// roundTenth rounds a value to the nearest tenth, which is the precision of differentials and indexes.
+func roundTenth(v float64) float64 {
+	return math.Round(v*10) / 10
+}
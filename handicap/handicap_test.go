@@ -0,0 +1,225 @@
+package handicap
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/C-Deck/ghin"
+)
+
+// testScores returns 18 hole scores with the differentials, from the most to the least recently played.
+func testScores(differentials ...float64) []ghin.Score {
+	out := make([]ghin.Score, len(differentials))
+	for i, d := range differentials {
+		out[i] = ghin.Score{
+			Id:            i + 1,
+			PlayedAt:      ghin.ToPlayedAtString(time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC).AddDate(0, 0, -i)),
+			NumberOfHoles: ghin.EighteenHolesPlayed,
+			Differential:  d,
+		}
+	}
+	return out
+}
+
+// sequence returns the differentials 1 to count.
+func sequence(count int) []float64 {
+	out := make([]float64, count)
+	for i := range out {
+		out[i] = float64(i + 1)
+	}
+	return out
+}
+
+// constant returns count copies of the differential.
+func constant(differential float64, count int) []float64 {
+	out := make([]float64, count)
+	for i := range out {
+		out[i] = differential
+	}
+	return out
+}
+
+func TestScoreDifferential(t *testing.T) {
+	tests := []struct {
+		name  string
+		ags   int
+		cr    float64
+		slope int
+		pcc   int
+		want  float64
+	}{
+		{name: "standard slope", ags: 85, cr: 72.0, slope: 113, want: 13.0},
+		{name: "harder course", ags: 85, cr: 71.3, slope: 131, want: 11.8},
+		{name: "playing conditions", ags: 85, cr: 72.0, slope: 113, pcc: 2, want: 11.0},
+		{name: "below the course rating", ags: 68, cr: 70.4, slope: 125, want: -2.2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ScoreDifferential(tt.ags, tt.cr, tt.slope, tt.pcc); got != tt.want {
+				t.Errorf("ScoreDifferential(%d, %.1f, %d, %d) = %.1f, want %.1f",
+					tt.ags, tt.cr, tt.slope, tt.pcc, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndex(t *testing.T) {
+	tests := []struct {
+		name          string
+		differentials []float64
+		want          float64
+	}{
+		{name: "3 scores", differentials: sequence(3), want: -1.0},
+		{name: "4 scores", differentials: sequence(4), want: 0.0},
+		{name: "5 scores", differentials: sequence(5), want: 1.0},
+		{name: "6 scores", differentials: sequence(6), want: 0.5},
+		{name: "7 scores", differentials: sequence(7), want: 1.5},
+		{name: "8 scores", differentials: sequence(8), want: 1.5},
+		{name: "9 scores", differentials: sequence(9), want: 2.0},
+		{name: "11 scores", differentials: sequence(11), want: 2.0},
+		{name: "12 scores", differentials: sequence(12), want: 2.5},
+		{name: "14 scores", differentials: sequence(14), want: 2.5},
+		{name: "15 scores", differentials: sequence(15), want: 3.0},
+		{name: "16 scores", differentials: sequence(16), want: 3.0},
+		{name: "17 scores", differentials: sequence(17), want: 3.5},
+		{name: "18 scores", differentials: sequence(18), want: 3.5},
+		{name: "19 scores", differentials: sequence(19), want: 4.0},
+		{name: "20 scores", differentials: sequence(20), want: 4.5},
+		{name: "only the 20 most recent count", differentials: append([]float64{100}, sequence(20)...), want: 4.5},
+		{name: "rounded to a tenth", differentials: []float64{10.1, 10.1, 10.2, 12, 13, 14, 15, 16, 17},
+			want: 10.1},
+		{name: "maximum index", differentials: constant(60, 20), want: MaxHandicapIndex},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Index(tt.differentials)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Index(%v) = %.1f, want %.1f", tt.differentials, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexInsufficientScores(t *testing.T) {
+	_, err := Index(sequence(2))
+	var insufficient *InsufficientScoresError
+	if !errors.As(err, &insufficient) || insufficient.Count != 2 {
+		t.Errorf("Index of 2 scores error = %v, want an InsufficientScoresError for 2 scores", err)
+	}
+}
+
+func TestCourseHandicap(t *testing.T) {
+	tests := []struct {
+		name  string
+		index float64
+		cr    float64
+		slope int
+		par   int
+		want  int
+	}{
+		{name: "standard course", index: 10.0, cr: 72.0, slope: 113, par: 72, want: 10},
+		{name: "slope and rating above par", index: 10.0, cr: 73.1, slope: 130, par: 72, want: 13},
+		{name: "rounded half up", index: 14.0, cr: 72.5, slope: 113, par: 72, want: 15},
+		{name: "plus handicap", index: -2.0, cr: 70.0, slope: 113, par: 72, want: -4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CourseHandicap(tt.index, tt.cr, tt.slope, tt.par); got != tt.want {
+				t.Errorf("CourseHandicap(%.1f, %.1f, %d, %d) = %d, want %d",
+					tt.index, tt.cr, tt.slope, tt.par, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlayingHandicap(t *testing.T) {
+	tests := []struct {
+		name      string
+		index     float64
+		allowance float64
+		want      int
+	}{
+		{name: "full allowance", index: 10.0, allowance: 100, want: 10},
+		{name: "stroke play allowance", index: 10.0, allowance: 95, want: 10},
+		{name: "unrounded course handicap", index: 12.4, allowance: 85, want: 11},
+		{name: "plus handicap", index: -4.0, allowance: 95, want: -4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PlayingHandicap(tt.index, 72.0, 113, 72, tt.allowance); got != tt.want {
+				t.Errorf("PlayingHandicap(%.1f, %.0f%%) = %d, want %d", tt.index, tt.allowance, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProject(t *testing.T) {
+	tests := []struct {
+		name          string
+		ags           int
+		wantProjected float64
+		wantReduction int
+	}{
+		{name: "replaces the oldest score", ags: 76, wantProjected: 9.3},
+		{name: "exceptional score", ags: 72, wantProjected: 6.8, wantReduction: -2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			projection, err := Project(ProjectionInput{
+				Scores: testScores(constant(10, 20)...),
+				Round:  Round{CourseRating: 72.0, SlopeRating: 113, AdjustedGrossScore: tt.ags},
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if projection.CurrentIndex != 10 || projection.ProjectedIndex != tt.wantProjected ||
+				projection.ExceptionalReduction != tt.wantReduction {
+				t.Errorf("projection = %+v, want current 10.0, projected %.1f with reduction %d",
+					projection, tt.wantProjected, tt.wantReduction)
+			}
+			if projection.Dropped == nil || projection.Dropped.Id != 20 {
+				t.Errorf("dropped = %+v, want the oldest score", projection.Dropped)
+			}
+		})
+	}
+}
+
+func TestProjectAppliesCaps(t *testing.T) {
+	low := 2.0
+	projection, err := Project(ProjectionInput{
+		Scores:   testScores(constant(10, 19)...),
+		Round:    Round{CourseRating: 72.0, SlopeRating: 113, AdjustedGrossScore: 82},
+		LowIndex: &low,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if projection.Caps == nil || projection.Caps.Cap != CapHard || projection.ProjectedIndex != 7.0 {
+		t.Errorf("projection = %+v with caps %+v, want the hard cap to limit the index to 7.0",
+			projection, projection.Caps)
+	}
+}
+
+func TestTargetScore(t *testing.T) {
+	input := ProjectionInput{
+		Scores: testScores(constant(10, 20)...),
+		Round:  Round{CourseRating: 72.0, SlopeRating: 113},
+	}
+	score, err := TargetScore(input, 9.3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if score != 76 {
+		t.Errorf("TargetScore = %d, want 76", score)
+	}
+
+	_, err = TargetScore(input, -5)
+	var unreachable *TargetUnreachableError
+	if !errors.As(err, &unreachable) {
+		t.Errorf("TargetScore error = %v, want a TargetUnreachableError", err)
+	}
+}
@@ -0,0 +1,154 @@
+package handicap
+
+import (
+	"math"
+	"sort"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+const (
+	expectedNineHoleIndexFactor = 0.52
+	expectedNineHoleConstant    = 1.2
+)
+
+// NineHolePair is an 18 hole score built from two 9 hole scores using the pairing method that was used before
+// 9 hole scores were combined with an expected score.
+type NineHolePair struct {
+	First              ghin.Score
+	Second             ghin.Score
+	AdjustedGrossScore int
+	CourseRating       float64
+	SlopeRating        int
+	Differential       float64
+}
+
+// RoundOnSide builds a Round for a score played on the given side of a tee set. Rounds on a single nine carry the
+// 9 hole ratings and are 9 hole rounds.
+func RoundOnSide(tee ghin.TeeSetDetails, side ghin.TeeSetSide, adjustedGrossScore int) (Round, error) {
+	rating := tee.Rating(side.RatingType())
+	if rating == nil {
+		return Round{}, errors.Errorf("tee set %q has no %s rating", tee.TeeSetRatingName, side.RatingType())
+	}
+	holes := ghin.EighteenHolesPlayed
+	if side != ghin.TeeSetSide18 {
+		holes = ghin.NineHolesPlayed
+	}
+	return Round{
+		CourseRating:       rating.CourseRating,
+		SlopeRating:        int(rating.SlopeRating),
+		AdjustedGrossScore: adjustedGrossScore,
+		NumberOfHoles:      holes,
+	}, nil
+}
+
+// ExpectedNineHoleDifferential is the differential a golfer with the given Handicap Index is expected to shoot over
+// nine holes.
+func ExpectedNineHoleDifferential(index float64) float64 {
+	return roundTenth(index*expectedNineHoleIndexFactor + expectedNineHoleConstant)
+}
+
+// ExpectedNineHoleScore is the adjusted gross score a golfer with the given Handicap Index is expected to shoot on a
+// nine rated with courseRating and slopeRating.
+func ExpectedNineHoleScore(index float64, courseRating float64, slopeRating int) int {
+	return int(math.Round(ExpectedNineHoleDifferential(index)*float64(slopeRating)/StandardSlopeRating + courseRating))
+}
+
+// NineHoleDifferential calculates the differential of the nine holes played. Only half of the playing conditions
+// calculation applies to a 9 hole score.
+func NineHoleDifferential(adjustedGrossScore int, courseRating float64, slopeRating int, pcc int) float64 {
+	return roundTenth(float64(StandardSlopeRating) / float64(slopeRating) *
+		(float64(adjustedGrossScore) - courseRating - float64(pcc)/2))
+}
+
+// EighteenHoleDifferential combines the differential of the nine holes played with the expected differential of the
+// nine that were not played.
+func EighteenHoleDifferential(nineHoleDifferential float64, index float64) float64 {
+	return roundTenth(nineHoleDifferential + ExpectedNineHoleDifferential(index))
+}
+
+// ScoreEighteenHoleDifferential calculates the 18 hole differential of a posted 9 hole score using the golfer's
+// Handicap Index at the time the score was played.
+func ScoreEighteenHoleDifferential(score ghin.Score, indexAtPlay float64) (float64, error) {
+	if score.NumberOfHoles != ghin.NineHolesPlayed {
+		return 0, errors.Errorf("score %d is not a 9 hole score", score.Id)
+	}
+	var pcc int
+	if score.Pcc != nil {
+		pcc = *score.Pcc
+	}
+	nine := NineHoleDifferential(score.AdjustedGrossScore, score.CourseRating, score.SlopeRating, pcc)
+	return EighteenHoleDifferential(nine, indexAtPlay), nil
+}
+
+// CombineNineHoleScores pairs two 9 hole scores into an 18 hole score by adding their scores and course ratings and
+// averaging their slope ratings.
+func CombineNineHoleScores(first, second ghin.Score) (*NineHolePair, error) {
+	for _, s := range []ghin.Score{first, second} {
+		if s.NumberOfHoles != ghin.NineHolesPlayed {
+			return nil, errors.Errorf("score %d is not a 9 hole score", s.Id)
+		}
+	}
+
+	out := &NineHolePair{
+		First:              first,
+		Second:             second,
+		AdjustedGrossScore: first.AdjustedGrossScore + second.AdjustedGrossScore,
+		CourseRating:       roundTenth(first.CourseRating + second.CourseRating),
+		SlopeRating:        int(math.Round(float64(first.SlopeRating+second.SlopeRating) / 2)),
+	}
+	var pcc int
+	for _, s := range []ghin.Score{first, second} {
+		if s.Pcc != nil && *s.Pcc > pcc {
+			pcc = *s.Pcc
+		}
+	}
+	out.Differential = ScoreDifferential(out.AdjustedGrossScore, out.CourseRating, out.SlopeRating, pcc)
+
+	return out, nil
+}
+
+// PairNineHoleScores pairs 9 hole scores in the order they were played. Scores that already belong to a combined
+// score (those with a ParentId) are ignored, and the last score is returned as unpaired when there is an odd number
+// of them.
+func PairNineHoleScores(scores []ghin.Score) ([]NineHolePair, []ghin.Score, error) {
+	var pending, unpaired []ghin.Score
+	for _, s := range scores {
+		if s.NumberOfHoles == ghin.NineHolesPlayed && s.ParentId == nil {
+			pending = append(pending, s)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		if pending[i].PlayedAt != pending[j].PlayedAt {
+			return pending[i].PlayedAt < pending[j].PlayedAt
+		}
+		return pending[i].ScoreDayOrder < pending[j].ScoreDayOrder
+	})
+
+	var pairs []NineHolePair
+	for i := 0; i+1 < len(pending); i += 2 {
+		pair, err := CombineNineHoleScores(pending[i], pending[i+1])
+		if err != nil {
+			return nil, nil, err
+		}
+		pairs = append(pairs, *pair)
+	}
+	if len(pending)%2 == 1 {
+		unpaired = append(unpaired, pending[len(pending)-1])
+	}
+
+	return pairs, unpaired, nil
+}
+
+// NineHoleComponents returns the 9 hole scores that GHIN combined into the given 18 hole score. Their names are
+// reported on the combined score by Front9CourseName and Back9CourseName.
+func NineHoleComponents(scores []ghin.Score, combined ghin.Score) []ghin.Score {
+	var out []ghin.Score
+	for _, s := range scores {
+		if s.ParentId != nil && *s.ParentId == combined.Id {
+			out = append(out, s)
+		}
+	}
+	return out
+}
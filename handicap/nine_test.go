@@ -0,0 +1,157 @@
+package handicap
+
+import (
+	"testing"
+
+	"github.com/C-Deck/ghin"
+)
+
+// nineHoleScore returns a 9 hole score played on the date.
+func nineHoleScore(id int, playedAt string, ags int, courseRating float64, slopeRating int) ghin.Score {
+	return ghin.Score{
+		Id:                 id,
+		PlayedAt:           playedAt,
+		NumberOfHoles:      ghin.NineHolesPlayed,
+		AdjustedGrossScore: ags,
+		CourseRating:       courseRating,
+		SlopeRating:        slopeRating,
+	}
+}
+
+func TestExpectedNineHoleDifferential(t *testing.T) {
+	tests := []struct {
+		index float64
+		want  float64
+	}{
+		{index: 0, want: 1.2},
+		{index: 10.0, want: 6.4},
+		{index: 25.3, want: 14.4},
+		{index: -2.0, want: 0.2},
+		{index: MaxHandicapIndex, want: 29.3},
+	}
+	for _, tt := range tests {
+		if got := ExpectedNineHoleDifferential(tt.index); got != tt.want {
+			t.Errorf("ExpectedNineHoleDifferential(%.1f) = %.1f, want %.1f", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestExpectedNineHoleScore(t *testing.T) {
+	if got := ExpectedNineHoleScore(10.0, 36.0, 113); got != 42 {
+		t.Errorf("ExpectedNineHoleScore on a standard nine = %d, want 42", got)
+	}
+	// 6.4 × 130 / 113 + 35.2 = 42.6
+	if got := ExpectedNineHoleScore(10.0, 35.2, 130); got != 43 {
+		t.Errorf("ExpectedNineHoleScore on a harder nine = %d, want 43", got)
+	}
+}
+
+func TestNineHoleDifferential(t *testing.T) {
+	if got := NineHoleDifferential(40, 36.0, 113, 0); got != 4.0 {
+		t.Errorf("NineHoleDifferential = %.1f, want 4.0", got)
+	}
+	// Only half of the playing conditions calculation applies over nine holes.
+	if got := NineHoleDifferential(40, 36.0, 113, 2); got != 3.0 {
+		t.Errorf("NineHoleDifferential with a PCC of 2 = %.1f, want 3.0", got)
+	}
+	if got := EighteenHoleDifferential(3.0, 10.0); got != 9.4 {
+		t.Errorf("EighteenHoleDifferential = %.1f, want 9.4", got)
+	}
+}
+
+func TestScoreEighteenHoleDifferential(t *testing.T) {
+	pcc := 2
+	score := nineHoleScore(1, "2024-06-01", 40, 36.0, 113)
+	score.Pcc = &pcc
+	got, err := ScoreEighteenHoleDifferential(score, 10.0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != 9.4 {
+		t.Errorf("ScoreEighteenHoleDifferential = %.1f, want 9.4", got)
+	}
+
+	if _, err := ScoreEighteenHoleDifferential(testScores(10)[0], 10.0); err == nil {
+		t.Error("expected an error for an 18 hole score")
+	}
+}
+
+func TestCombineNineHoleScores(t *testing.T) {
+	pcc := 1
+	first := nineHoleScore(1, "2024-06-01", 40, 36.1, 120)
+	second := nineHoleScore(2, "2024-06-08", 42, 35.9, 125)
+	second.Pcc = &pcc
+
+	pair, err := CombineNineHoleScores(first, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 113 / 123 × (82 - 72.0 - 1) = 8.3
+	if pair.AdjustedGrossScore != 82 || pair.CourseRating != 72.0 || pair.SlopeRating != 123 ||
+		pair.Differential != 8.3 {
+		t.Errorf("pair = %+v, want 82 on a 72.0/123 rating with a differential of 8.3", pair)
+	}
+
+	if _, err := CombineNineHoleScores(first, testScores(10)[0]); err == nil {
+		t.Error("expected an error for an 18 hole score")
+	}
+}
+
+func TestPairNineHoleScores(t *testing.T) {
+	parent := 99
+	combined := nineHoleScore(4, "2024-05-01", 40, 36.0, 113)
+	combined.ParentId = &parent
+	scores := []ghin.Score{
+		nineHoleScore(3, "2024-06-15", 44, 36.0, 113),
+		nineHoleScore(1, "2024-06-01", 40, 36.0, 113),
+		combined,
+		testScores(10)[0],
+		nineHoleScore(2, "2024-06-08", 42, 36.0, 113),
+	}
+
+	pairs, unpaired, err := PairNineHoleScores(scores)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pairs) != 1 || pairs[0].First.Id != 1 || pairs[0].Second.Id != 2 {
+		t.Errorf("pairs = %+v, want the two oldest 9 hole scores paired", pairs)
+	}
+	if len(unpaired) != 1 || unpaired[0].Id != 3 {
+		t.Errorf("unpaired = %+v, want the most recent 9 hole score", unpaired)
+	}
+
+	if components := NineHoleComponents(scores, ghin.Score{Id: parent}); len(components) != 1 ||
+		components[0].Id != 4 {
+		t.Errorf("components = %+v, want the score combined into score %d", components, parent)
+	}
+}
+
+func TestProjectNineHoleRound(t *testing.T) {
+	tee := ghin.TeeSetDetails{
+		TeeSetRatingName: "Blue",
+		Ratings: []ghin.TeeSetRating{
+			{TeeSetRatingType: string(ghin.TeeSetRatingTypeTotal), CourseRating: 72.0, SlopeRating: 113},
+			{TeeSetRatingType: string(ghin.TeeSetRatingTypeFront), CourseRating: 36.0, SlopeRating: 113},
+		},
+	}
+	round, err := RoundOnSide(tee, ghin.TeeSetSideFront, 40)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if round.NumberOfHoles != ghin.NineHolesPlayed || round.Differential() != 4.0 {
+		t.Fatalf("round = %+v, want a 9 hole round with a differential of 4.0", round)
+	}
+
+	projection, err := Project(ProjectionInput{Scores: testScores(constant(10, 20)...), Round: round})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 4.0 + 0.52 × 10.0 + 1.2 = 10.4
+	if projection.Differential != 10.4 {
+		t.Errorf("Differential = %.1f, want the 18 hole differential 10.4", projection.Differential)
+	}
+
+	if _, err := Project(ProjectionInput{Round: round}); err == nil {
+		t.Error("expected an error projecting a 9 hole round without a Handicap Index")
+	}
+}
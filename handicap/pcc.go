@@ -0,0 +1,101 @@
+package handicap
+
+import (
+	"math"
+	"sort"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+const (
+	// MinPCCScores is the minimum number of eligible scores needed to estimate a playing conditions calculation.
+	MinPCCScores = 8
+
+	// MaxPCCIndex is the highest Handicap Index of a golfer whose score is used in a playing conditions calculation.
+	MaxPCCIndex = 36.0
+
+	MinPCC = -1
+	MaxPCC = 3
+
+	// expectedDifferentialGap is how far above their Handicap Index golfers typically shoot in normal conditions,
+	// since the index is built from their best differentials.
+	expectedDifferentialGap = 3.0
+
+	// pccTrimFraction is the fraction of the highest and lowest results ignored as outliers.
+	pccTrimFraction = 0.1
+)
+
+// PCCEntry is a score posted at a course on the day being evaluated.
+type PCCEntry struct {
+	// Differential is the differential of the score before any playing conditions calculation.
+	Differential float64
+	// Index is the Handicap Index of the golfer when the score was played.
+	Index float64
+}
+
+type PCCEstimate struct {
+	// Adjustment is the estimated playing conditions calculation, between MinPCC and MaxPCC.
+	Adjustment int
+	// Eligible is the number of entries used in the estimate.
+	Eligible int
+	// AverageExcess is how many strokes above their expected differential golfers played on average.
+	AverageExcess float64
+}
+
+// EstimatePCC approximates the playing conditions calculation GHIN would apply to the scores posted at a course on a
+// day. Only golfers with a Handicap Index of at most MaxPCCIndex are considered, the most extreme results are
+// ignored, and the remaining excess over the expected differential is rounded and clamped to the allowed range.
+func EstimatePCC(entries []PCCEntry) (*PCCEstimate, error) {
+	var excess []float64
+	for _, e := range entries {
+		if e.Index > MaxPCCIndex {
+			continue
+		}
+		excess = append(excess, e.Differential-e.Index-expectedDifferentialGap)
+	}
+	if len(excess) < MinPCCScores {
+		return nil, errors.Errorf("at least %d eligible scores are required to estimate the playing conditions, got %d",
+			MinPCCScores, len(excess))
+	}
+
+	sort.Float64s(excess)
+	trim := int(float64(len(excess)) * pccTrimFraction)
+	excess = excess[trim : len(excess)-trim]
+
+	var total float64
+	for _, e := range excess {
+		total += e
+	}
+	out := &PCCEstimate{
+		Eligible:      len(excess),
+		AverageExcess: roundTenth(total / float64(len(excess))),
+	}
+	out.Adjustment = int(math.Round(out.AverageExcess))
+	if out.Adjustment < MinPCC {
+		out.Adjustment = MinPCC
+	}
+	if out.Adjustment > MaxPCC {
+		out.Adjustment = MaxPCC
+	}
+
+	return out, nil
+}
+
+// PCCEntriesFromScores builds the entries for a playing conditions estimate from 18 hole scores. Indexes are the
+// Handicap Indexes of the golfers at the time of play keyed by golfer ID; scores of golfers without an index are
+// skipped. The differentials are recalculated without the PCC the scores may already carry.
+func PCCEntriesFromScores(scores []ghin.Score, indexes map[string]float64) []PCCEntry {
+	var out []PCCEntry
+	for _, s := range scores {
+		index, ok := indexes[s.GolferId]
+		if !ok || s.NumberOfHoles != ghin.EighteenHolesPlayed {
+			continue
+		}
+		out = append(out, PCCEntry{
+			Differential: ScoreDifferential(s.AdjustedGrossScore, s.CourseRating, s.SlopeRating, 0),
+			Index:        index,
+		})
+	}
+	return out
+}
@@ -0,0 +1,94 @@
+package handicap
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/C-Deck/ghin"
+)
+
+// pccEntries returns entries of golfers with a Handicap Index of 10 who played excess strokes above their expected
+// differential.
+func pccEntries(excess ...float64) []PCCEntry {
+	out := make([]PCCEntry, len(excess))
+	for i, e := range excess {
+		out[i] = PCCEntry{Differential: 10 + expectedDifferentialGap + e, Index: 10}
+	}
+	return out
+}
+
+func TestEstimatePCC(t *testing.T) {
+	tests := []struct {
+		name    string
+		entries []PCCEntry
+		want    PCCEstimate
+	}{
+		{
+			name:    "normal conditions",
+			entries: pccEntries(0, 0, 0, 0, 0, 0, 0, 0),
+			want:    PCCEstimate{Adjustment: 0, Eligible: 8},
+		},
+		{
+			name:    "difficult conditions",
+			entries: pccEntries(2, 2, 2, 2, 2, 2, 2, 2, 2, 2),
+			want:    PCCEstimate{Adjustment: 2, Eligible: 8, AverageExcess: 2},
+		},
+		{
+			name:    "outliers are trimmed",
+			entries: pccEntries(-20, 1, 1, 1, 1, 1, 1, 1, 1, 30),
+			want:    PCCEstimate{Adjustment: 1, Eligible: 8, AverageExcess: 1},
+		},
+		{
+			name:    "clamped to the maximum",
+			entries: pccEntries(6, 6, 6, 6, 6, 6, 6, 6),
+			want:    PCCEstimate{Adjustment: MaxPCC, Eligible: 8, AverageExcess: 6},
+		},
+		{
+			name:    "clamped to the minimum",
+			entries: pccEntries(-4, -4, -4, -4, -4, -4, -4, -4),
+			want:    PCCEstimate{Adjustment: MinPCC, Eligible: 8, AverageExcess: -4},
+		},
+		{
+			name: "high indexes are ignored",
+			entries: append(pccEntries(0, 0, 0, 0, 0, 0, 0, 0),
+				PCCEntry{Differential: 60, Index: MaxPCCIndex + 0.1}),
+			want: PCCEstimate{Adjustment: 0, Eligible: 8},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EstimatePCC(tt.entries)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if *got != tt.want {
+				t.Errorf("EstimatePCC = %+v, want %+v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEstimatePCCTooFewScores(t *testing.T) {
+	entries := append(pccEntries(0, 0, 0, 0, 0, 0, 0), PCCEntry{Differential: 20, Index: 40})
+	if _, err := EstimatePCC(entries); err == nil {
+		t.Errorf("expected an error with fewer than %d eligible scores", MinPCCScores)
+	}
+}
+
+func TestPCCEntriesFromScores(t *testing.T) {
+	pcc := 2
+	scores := []ghin.Score{
+		{GolferId: "1", NumberOfHoles: ghin.EighteenHolesPlayed, AdjustedGrossScore: 85, CourseRating: 72.0,
+			SlopeRating: 113, Pcc: &pcc, Differential: 11.0},
+		{GolferId: "2", NumberOfHoles: ghin.NineHolesPlayed, AdjustedGrossScore: 42, CourseRating: 36.0,
+			SlopeRating: 113},
+		{GolferId: "3", NumberOfHoles: ghin.EighteenHolesPlayed, AdjustedGrossScore: 90, CourseRating: 72.0,
+			SlopeRating: 113},
+	}
+	indexes := map[string]float64{"1": 9.5, "2": 12.0}
+
+	want := []PCCEntry{{Differential: 13.0, Index: 9.5}}
+	if got := PCCEntriesFromScores(scores, indexes); !reflect.DeepEqual(got, want) {
+		t.Errorf("PCCEntriesFromScores = %+v, want %+v", got, want)
+	}
+}
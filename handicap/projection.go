@@ -0,0 +1,139 @@
+package handicap
+
+import (
+	"math"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+// Round is a hypothetical round used to project a Handicap Index.
+type Round struct {
+	// CourseRating is the course rating of the tee set played.
+	CourseRating float64
+	// SlopeRating is the slope rating of the tee set played.
+	SlopeRating int
+	// AdjustedGrossScore is the score of the round after any hole score adjustments.
+	AdjustedGrossScore int
+	// PCC is the playing conditions calculation expected for the day of the round.
+	PCC int
+	// NumberOfHoles is the number of holes of the round. The course and slope ratings of a 9 hole round are those of
+	// the nine played. Rounds with no number of holes are 18 hole rounds.
+	NumberOfHoles ghin.HolesPlayed
+}
+
+// RoundOnTee builds a Round for an 18 hole score played on the given tee set.
+func RoundOnTee(tee ghin.TeeSetDetails, adjustedGrossScore int) (Round, error) {
+	return RoundOnSide(tee, ghin.TeeSetSide18, adjustedGrossScore)
+}
+
+// Differential returns the score differential of the round, which is the 9 hole differential of a 9 hole round.
+func (r Round) Differential() float64 {
+	if r.NumberOfHoles == ghin.NineHolesPlayed {
+		return NineHoleDifferential(r.AdjustedGrossScore, r.CourseRating, r.SlopeRating, r.PCC)
+	}
+	return ScoreDifferential(r.AdjustedGrossScore, r.CourseRating, r.SlopeRating, r.PCC)
+}
+
+type ProjectionInput struct {
+	// Scores are the golfer's posted scores. Only the most recent ScoringRecordSize scores are considered.
+	Scores []ghin.Score
+
+	// Round is the hypothetical round to add to the scoring record.
+	Round Round
+
+	/* LowIndex is the golfer's Low Handicap Index over the last 365 days.
+	 * Default: Caps are not evaluated
+	 */
+	LowIndex *float64
+}
+
+type Projection struct {
+	// CurrentIndex is the Handicap Index calculated from the scoring record before the round.
+	CurrentIndex float64
+	// ProjectedIndex is the Handicap Index calculated once the round has been added to the scoring record.
+	ProjectedIndex float64
+	// Differential is the score differential of the round. The differential of a 9 hole round is its 18 hole
+	// differential, see EighteenHoleDifferential.
+	Differential float64
+	// Dropped is the score that leaves the scoring record to make room for the round, if any.
+	Dropped *ghin.Score
+	// Caps is how the soft and hard caps affected the projected index. It is nil when caps were not evaluated.
+	Caps *CapResult
+	// ExceptionalReduction is the adjustment applied to each differential by an exceptional score reduction.
+	ExceptionalReduction int
+}
+
+// Project calculates the Handicap Index a golfer would have after posting the round. A 9 hole round is converted to
+// an 18 hole differential using the current Handicap Index, so it cannot be projected without one.
+func Project(input ProjectionInput) (*Projection, error) {
+	scores := append([]ghin.Score(nil), input.Scores...)
+	SortMostRecentFirst(scores)
+	if len(scores) > ScoringRecordSize {
+		scores = scores[:ScoringRecordSize]
+	}
+
+	out := &Projection{Differential: input.Round.Differential()}
+
+	currentIndex, err := Index(AdjustedDifferentials(scores))
+	hasCurrentIndex := err == nil
+	if hasCurrentIndex {
+		out.CurrentIndex = currentIndex
+	}
+
+	if input.Round.NumberOfHoles == ghin.NineHolesPlayed {
+		if !hasCurrentIndex {
+			return nil, errors.Wrapf(err, "problem calculating the 18 hole differential of a 9 hole round")
+		}
+		out.Differential = EighteenHoleDifferential(out.Differential, currentIndex)
+	}
+
+	if len(scores) == ScoringRecordSize {
+		dropped := scores[ScoringRecordSize-1]
+		out.Dropped = &dropped
+		scores = scores[:ScoringRecordSize-1]
+	}
+	differentials := append([]float64{out.Differential}, AdjustedDifferentials(scores)...)
+
+	if hasCurrentIndex {
+		out.ExceptionalReduction = ExceptionalReduction(out.Differential, currentIndex)
+		differentials = ApplyExceptionalReduction(differentials, out.ExceptionalReduction)
+	}
+
+	projected, err := Index(differentials)
+	if err != nil {
+		return nil, err
+	}
+	if input.LowIndex != nil {
+		caps := ApplyCaps(projected, *input.LowIndex)
+		out.Caps = &caps
+		projected = caps.Capped
+	}
+	out.ProjectedIndex = projected
+
+	return out, nil
+}
+
+// TargetScore calculates the highest adjusted gross score that results in a projected Handicap Index at or below
+// target. The AdjustedGrossScore of the input round is ignored. Scores are searched up to twice the course rating,
+// which is returned if every score in that range reaches the target.
+func TargetScore(input ProjectionInput, target float64) (int, error) {
+	best := 0
+	limit := int(math.Ceil(input.Round.CourseRating * 2))
+	for score := 1; score <= limit; score++ {
+		input.Round.AdjustedGrossScore = score
+		projection, err := Project(input)
+		if err != nil {
+			return 0, err
+		}
+		if projection.ProjectedIndex > target {
+			break
+		}
+		best = score
+	}
+
+	if best == 0 {
+		return 0, NewTargetUnreachableError(target)
+	}
+	return best, nil
+}
@@ -0,0 +1,246 @@
+package ghin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// QueueStatus represents where a queued score is in the submission process.
+type QueueStatus string
+
+const (
+	QueueStatusPending   QueueStatus = "Pending"
+	QueueStatusSubmitted QueueStatus = "Submitted"
+	QueueStatusFailed    QueueStatus = "Failed"
+
+	queueFileExtension = ".json"
+	queueTempPattern   = ".queued-*.tmp"
+)
+
+// QueuedScore is a score waiting in a ScoreQueue, along with the outcome of its submission once it is known.
+type QueuedScore struct {
+	// ID identifies the score in the queue. IDs sort in the order the scores were queued.
+	ID string `json:"id"`
+	// Input is the score to submit.
+	Input SubmitScoreInput `json:"input"`
+	// QueuedAt is when the score was added to the queue.
+	QueuedAt time.Time `json:"queued_at"`
+	// Status is whether the score is still waiting, was submitted or was rejected.
+	Status QueueStatus `json:"status"`
+	// Attempts is the number of times the score has been sent to GHIN.
+	Attempts int `json:"attempts"`
+	// LastError is the error returned by the most recent attempt, if it failed.
+	LastError string `json:"last_error,omitempty"`
+	// Score is the score posted to GHIN once the submission succeeded.
+	Score *Score `json:"score,omitempty"`
+	// CompletedAt is when the score was submitted or rejected.
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+}
+
+// ScoreQueue stores scores on disk until they can be submitted, so that scores entered without connectivity are
+// not lost when the process restarts. Each score is kept in its own JSON file in the queue directory.
+type ScoreQueue struct {
+	dir    string
+	client *Client
+	mu     sync.Mutex
+}
+
+// NewScoreQueue opens the queue stored in dir, creating the directory if needed. Scores are submitted with client.
+func NewScoreQueue(dir string, client *Client) (*ScoreQueue, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, errors.Wrapf(err, "problem creating score queue directory %q", dir)
+	}
+	return &ScoreQueue{dir: dir, client: client}, nil
+}
+
+// Enqueue stores a score to submit later. The played date defaults to the current date when the score is queued
+// rather than when it is eventually submitted.
+func (q *ScoreQueue) Enqueue(input SubmitScoreInput) (*QueuedScore, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now()
+	if input.PlayedAt == nil {
+		input.PlayedAt = &now
+	}
+	queued := &QueuedScore{
+		Input:    input,
+		QueuedAt: now,
+		Status:   QueueStatusPending,
+	}
+	for id := now.UnixNano(); ; id++ {
+		queued.ID = fmt.Sprintf("%020d", id)
+		_, err := os.Stat(q.path(queued.ID))
+		if errors.Is(err, os.ErrNotExist) {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem checking for queued score %s", queued.ID)
+		}
+	}
+
+	if err := q.write(queued); err != nil {
+		return nil, err
+	}
+	return queued, nil
+}
+
+// List returns every score in the queue in the order they were queued, including those that were already
+// submitted or rejected.
+func (q *ScoreQueue) List() ([]QueuedScore, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return q.list()
+}
+
+// Pending returns the scores that still need to be submitted in the order they were queued.
+func (q *ScoreQueue) Pending() ([]QueuedScore, error) {
+	all, err := q.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []QueuedScore
+	for _, s := range all {
+		if s.Status == QueueStatusPending {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// Flush submits the pending scores in the order they were queued and returns the scores it attempted. Scores that
+// fail validation are marked as failed and skipped. Any other error stops the flush so that the remaining scores
+// keep their order, and is returned after the attempt has been recorded.
+func (q *ScoreQueue) Flush(ctx context.Context) ([]QueuedScore, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	all, err := q.list()
+	if err != nil {
+		return nil, err
+	}
+
+	var out []QueuedScore
+	for _, queued := range all {
+		if queued.Status != QueueStatusPending {
+			continue
+		}
+
+		queued.Attempts++
+		score, submitErr := q.client.SubmitScore(ctx, queued.Input)
+		var validationErr *ValidationError
+		switch {
+		case submitErr == nil:
+			completed := time.Now()
+			queued.Status = QueueStatusSubmitted
+			queued.Score = score
+			queued.LastError = ""
+			queued.CompletedAt = &completed
+		case errors.As(submitErr, &validationErr):
+			completed := time.Now()
+			queued.Status = QueueStatusFailed
+			queued.LastError = submitErr.Error()
+			queued.CompletedAt = &completed
+		default:
+			queued.LastError = submitErr.Error()
+		}
+
+		if err := q.write(&queued); err != nil {
+			return out, err
+		}
+		out = append(out, queued)
+		if queued.Status == QueueStatusPending {
+			return out, errors.Wrapf(submitErr, "problem submitting queued score %s", queued.ID)
+		}
+	}
+
+	return out, nil
+}
+
+// Remove deletes a score from the queue.
+func (q *ScoreQueue) Remove(id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err := os.Remove(q.path(id)); err != nil {
+		return errors.Wrapf(err, "problem removing queued score %s", id)
+	}
+	return nil
+}
+
+func (q *ScoreQueue) path(id string) string {
+	return filepath.Join(q.dir, id+queueFileExtension)
+}
+
+func (q *ScoreQueue) list() ([]QueuedScore, error) {
+	entries, err := os.ReadDir(q.dir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem reading score queue directory %q", q.dir)
+	}
+
+	var out []QueuedScore
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || strings.HasPrefix(name, ".") || filepath.Ext(name) != queueFileExtension {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(q.dir, name))
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem reading queued score %q", name)
+		}
+		var queued QueuedScore
+		if err := json.Unmarshal(data, &queued); err != nil {
+			return nil, errors.Wrapf(err, "problem deserializing queued score %q", name)
+		}
+		out = append(out, queued)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].ID < out[j].ID
+	})
+
+	return out, nil
+}
+
+// write stores the queued score by writing it to a temporary file and renaming it over the previous version, so
+// that a crash never leaves a partially written score behind.
+func (q *ScoreQueue) write(queued *QueuedScore) error {
+	data, err := json.MarshalIndent(queued, "", "  ")
+	if err != nil {
+		return errors.Wrapf(err, "problem serializing queued score %s", queued.ID)
+	}
+
+	tmp, err := os.CreateTemp(q.dir, queueTempPattern)
+	if err != nil {
+		return errors.Wrapf(err, "problem creating file for queued score %s", queued.ID)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "problem writing queued score %s", queued.ID)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "problem writing queued score %s", queued.ID)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "problem writing queued score %s", queued.ID)
+	}
+	if err := os.Rename(tmp.Name(), q.path(queued.ID)); err != nil {
+		return errors.Wrapf(err, "problem saving queued score %s", queued.ID)
+	}
+
+	return nil
+}
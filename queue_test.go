@@ -0,0 +1,178 @@
+package ghin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeGHIN answers the requests SubmitScore makes. Posted scores get the next status code of statuses, or 200 once
+// they run out.
+type fakeGHIN struct {
+	statuses []int
+	posted   []ScoreSubmission
+}
+
+func (f *fakeGHIN) Do(req *http.Request) (*http.Response, error) {
+	status, body := http.StatusOK, `{"scores": []}`
+	if strings.HasSuffix(req.URL.Path, postScorePath) {
+		var submission ScoreSubmission
+		if err := json.NewDecoder(req.Body).Decode(&submission); err != nil {
+			return nil, err
+		}
+		if len(f.statuses) > 0 {
+			status, f.statuses = f.statuses[0], f.statuses[1:]
+		}
+		if status == http.StatusOK {
+			f.posted = append(f.posted, submission)
+		}
+		body = fmt.Sprintf(`{"id": %d}`, len(f.posted))
+	}
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}, nil
+}
+
+func newTestQueue(t *testing.T, server *fakeGHIN) *ScoreQueue {
+	t.Helper()
+	client := &Client{client: &httpClient{baseURL: baseURL, Client: server}, user: &User{GolferId: 1}}
+	queue, err := NewScoreQueue(t.TempDir(), client)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return queue
+}
+
+// queueInput returns an 18 hole score on the course.
+func queueInput(courseID int) SubmitScoreInput {
+	playedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	input := SubmitScoreInput{Gender: PlayerGenderMale, CourseID: courseID, TeeSetID: 1, PlayedAt: &playedAt}
+	for i := 1; i <= 18; i++ {
+		input.HoleDetails = append(input.HoleDetails, HoleScore{HoleNumber: i, RawScore: 4})
+	}
+	return input
+}
+
+func enqueue(t *testing.T, queue *ScoreQueue, inputs ...SubmitScoreInput) {
+	t.Helper()
+	for _, input := range inputs {
+		if _, err := queue.Enqueue(input); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestScoreQueueEnqueue(t *testing.T) {
+	queue := newTestQueue(t, &fakeGHIN{})
+	enqueue(t, queue, queueInput(1), queueInput(2), queueInput(3))
+
+	entries, err := os.ReadDir(queue.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if !strings.HasSuffix(entry.Name(), queueFileExtension) {
+			t.Errorf("queue directory has %q, want only the files of queued scores", entry.Name())
+		}
+	}
+
+	pending, err := queue.Pending()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 3 {
+		t.Fatalf("pending = %+v, want 3 scores", pending)
+	}
+	for i, queued := range pending {
+		if queued.Input.CourseID != i+1 || queued.Status != QueueStatusPending {
+			t.Errorf("pending[%d] = %+v, want the pending score on course %d", i, queued, i+1)
+		}
+	}
+
+	if err := queue.Remove(pending[1].ID); err != nil {
+		t.Fatal(err)
+	}
+	if all, err := queue.List(); err != nil || len(all) != 2 {
+		t.Errorf("List after Remove = %+v, %v, want 2 scores", all, err)
+	}
+}
+
+func TestScoreQueueFlush(t *testing.T) {
+	invalid := queueInput(2)
+	invalid.HoleDetails = invalid.HoleDetails[1:]
+
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantErr      bool
+		wantStatuses []QueueStatus
+		wantPosted   []int
+	}{
+		{
+			name:         "submits in queued order",
+			wantStatuses: []QueueStatus{QueueStatusSubmitted, QueueStatusFailed, QueueStatusSubmitted},
+			wantPosted:   []int{1, 3},
+		},
+		{
+			name:         "stops at the first request error",
+			statuses:     []int{http.StatusInternalServerError},
+			wantErr:      true,
+			wantStatuses: []QueueStatus{QueueStatusPending, QueueStatusPending, QueueStatusPending},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := &fakeGHIN{statuses: tt.statuses}
+			queue := newTestQueue(t, server)
+			enqueue(t, queue, queueInput(1), invalid, queueInput(3))
+
+			_, err := queue.Flush(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Flush error = %v, want error %t", err, tt.wantErr)
+			}
+
+			all, err := queue.List()
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, queued := range all {
+				if queued.Status != tt.wantStatuses[i] {
+					t.Errorf("score %d status = %s, want %s", i, queued.Status, tt.wantStatuses[i])
+				}
+			}
+			if tt.wantErr && (all[0].Attempts != 1 || all[0].LastError == "" || all[1].Attempts != 0) {
+				t.Errorf("scores = %+v, want only the first attempted with its error recorded", all)
+			}
+
+			var posted []int
+			for _, s := range server.posted {
+				posted = append(posted, s.CourseID)
+			}
+			if fmt.Sprint(posted) != fmt.Sprint(tt.wantPosted) {
+				t.Errorf("posted courses = %v, want %v", posted, tt.wantPosted)
+			}
+		})
+	}
+}
+
+func TestScoreQueueFlushRetries(t *testing.T) {
+	server := &fakeGHIN{statuses: []int{http.StatusServiceUnavailable}}
+	queue := newTestQueue(t, server)
+	enqueue(t, queue, queueInput(1))
+
+	if _, err := queue.Flush(context.Background()); err == nil {
+		t.Fatal("expected the first flush to fail")
+	}
+	flushed, err := queue.Flush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(flushed) != 1 || flushed[0].Status != QueueStatusSubmitted || flushed[0].Attempts != 2 ||
+		flushed[0].LastError != "" || flushed[0].Score == nil || flushed[0].CompletedAt == nil {
+		t.Errorf("flushed = %+v, want the score submitted on its second attempt", flushed)
+	}
+}
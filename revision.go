@@ -0,0 +1,85 @@
+package ghin
+
+import (
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+type (
+	// HandicapRevision is a Handicap Index issued to a golfer on a revision date.
+	HandicapRevision struct {
+		Id           int     `json:"ID"`
+		GhinNumber   int     `json:"GHINNumber"`
+		RevDate      string  `json:"RevDate"`
+		Display      string  `json:"Display"`
+		Value        float64 `json:"Value"`
+		LowHIDisplay string  `json:"LowHIDisplay"`
+		LowHI        float64 `json:"LowHIValue"`
+		SoftCap      string  `json:"Soft_Cap"`
+		HardCap      string  `json:"Hard_Cap"`
+	}
+
+	// RevisionScores is a HandicapRevision with the scores played since the previous revision.
+	RevisionScores struct {
+		Revision HandicapRevision
+		Scores   []Score
+	}
+)
+
+const (
+	revDateFormat = "2006-01-02T15:04:05"
+)
+
+// ParseRevDate parses the date format used by the RevDate fields of golfers and handicap revisions.
+func ParseRevDate(s string) (time.Time, error) {
+	t, err := time.Parse(revDateFormat, s)
+	if err == nil {
+		return t, nil
+	}
+	return time.Parse(playedAtDateFormat, s)
+}
+
+// Date returns the date the revision was issued.
+func (r HandicapRevision) Date() (time.Time, error) {
+	return ParseRevDate(r.RevDate)
+}
+
+// CorrelateRevisions groups scores with the revision that first included them. The revisions are returned from
+// oldest to most recent, and scores played on or after the most recent revision are not included.
+func CorrelateRevisions(revisions []HandicapRevision, scores []Score) ([]RevisionScores, error) {
+	dates := make([]time.Time, len(revisions))
+	order := make([]int, len(revisions))
+	for i, r := range revisions {
+		date, err := r.Date()
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem parsing date of revision %d", r.Id)
+		}
+		dates[i] = date
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return dates[order[i]].Before(dates[order[j]])
+	})
+
+	out := make([]RevisionScores, len(revisions))
+	for i, o := range order {
+		out[i].Revision = revisions[o]
+	}
+
+	for _, s := range scores {
+		playedAt, err := ParsePlayedAt(s.PlayedAt)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem parsing played date of score %d", s.Id)
+		}
+		i := sort.Search(len(out), func(i int) bool {
+			return dates[order[i]].After(playedAt)
+		})
+		if i < len(out) {
+			out[i].Scores = append(out[i].Scores, s)
+		}
+	}
+
+	return out, nil
+}
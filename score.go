@@ -1,6 +1,8 @@
 package ghin
 
 import (
+	"math"
+	"strconv"
 	"time"
 )
 
@@ -53,6 +55,39 @@ func (g PlayerGender) LongString() string {
 	return "Female"
 }
 
+// String returns the direction of the miss.
+func (a ShotAccuracy) String() string {
+	switch a {
+	case ShotAccuracyMissedLeft:
+		return "Left"
+	case ShotAccuracyMissedRight:
+		return "Right"
+	case ShotAccuracyMissedLong:
+		return "Long"
+	case ShotAccuracyMissedShort:
+		return "Short"
+	}
+	return strconv.Itoa(int(a))
+}
+
+// RatingType returns the type of tee set rating that applies to the holes played.
+func (s TeeSetSide) RatingType() TeeSetRatingType {
+	switch s {
+	case TeeSetSideFront:
+		return TeeSetRatingTypeFront
+	case TeeSetSideBack:
+		return TeeSetRatingTypeBack
+	}
+	return TeeSetRatingTypeTotal
+}
+
+type MaxHoleScore struct {
+	// HoleNumber is the hole number the maximum score is for.
+	HoleNumber int `json:"hole_number"`
+	// MaxScore is the highest score that counts towards the adjusted gross score for the hole.
+	MaxScore int `json:"maximum_score"`
+}
+
 type ScoreSubmission struct {
 	// GolferID is the ID of the golfer that the ScoreSubmission is for.
 	GolferID string `json:"golfer_id"`
@@ -77,6 +112,48 @@ type ScoreSubmission struct {
 	Source               *string     `json:"source,omitempty"`
 }
 
+type TotalScoreSubmission struct {
+	// GolferID is the ID of the golfer that the TotalScoreSubmission is for.
+	GolferID string `json:"golfer_id"`
+	// Gender is the gender of the golfer.
+	Gender PlayerGender `json:"gender"`
+	// CourseID is the ID of the course played.
+	CourseID int `json:"course_id"`
+	// TeeSetID is the ID of the tee box that was played (whites, blues, etc) for the course.
+	TeeSetID int `json:"tee_set_id"`
+	// TeeSetSide represents the which holes were played. Front/Back 9 or that 18 holes.
+	TeeSetSide TeeSetSide `json:"tee_set_side"`
+	// PlayedAt is the date the round was played.
+	PlayedAt string `json:"played_at"`
+	// NumberOfHoles represents whether 9 or 18 holes were played.
+	NumberOfHoles HolesPlayed `json:"number_of_holes"`
+	// ScoreType is the situation the round was played (Away, Home, or Tournament).
+	ScoreType ScoringType `json:"score_type"`
+	// AdjustedGrossScore is the total score of the round after any hole score adjustments.
+	AdjustedGrossScore int `json:"adjusted_gross_score"`
+	// FrontNineScore is the adjusted score of the front nine.
+	FrontNineScore *int `json:"front9_adjusted,omitempty"`
+	// BackNineScore is the adjusted score of the back nine.
+	BackNineScore        *int  `json:"back9_adjusted,omitempty"`
+	OverrideConfirmation *bool `json:"override_confirmation,omitempty"`
+	IsManual             *bool `json:"is_manual"`
+}
+
+type ScoreUpdate struct {
+	// CourseID is the ID of the course played.
+	CourseID *int `json:"course_id,omitempty"`
+	// TeeSetID is the ID of the tee box that was played (whites, blues, etc) for the course.
+	TeeSetID *int `json:"tee_set_id,omitempty"`
+	// TeeSetSide represents the which holes were played. Front/Back 9 or that 18 holes.
+	TeeSetSide *TeeSetSide `json:"tee_set_side,omitempty"`
+	// PlayedAt is the date the round was played.
+	PlayedAt *string `json:"played_at,omitempty"`
+	// HoleDetails are the scores and statistics for each hole.
+	HoleDetails []HoleScore `json:"hole_details,omitempty"`
+	// ScoreType is the situation the round was played (Away, Home, or Tournament).
+	ScoreType *ScoringType `json:"score_type,omitempty"`
+}
+
 type HoleScoreDetails struct {
 	HoleScore
 	AdjustedGrossScore int `json:"adjusted_gross_score"`
@@ -223,8 +300,78 @@ type ScoreAdjustment struct {
 
 const (
 	playedAtDateFormat = "2006-01-02"
+
+	// duplicateSearchLimit is the number of recent scores checked for a duplicate before a score is submitted.
+	duplicateSearchLimit = 20
+
+	// StandardSlopeRating is the slope rating of a course of standard playing difficulty.
+	StandardSlopeRating = 113
 )
 
 func ToPlayedAtString(t time.Time) string {
 	return t.Format(playedAtDateFormat)
 }
+
+// ParsePlayedAt parses the date format used by the PlayedAt fields of scores.
+func ParsePlayedAt(s string) (time.Time, error) {
+	return time.Parse(playedAtDateFormat, s)
+}
+
+// AdjustedGrossScore totals the hole scores after limiting each of them to the maximum score of the hole. Holes
+// without a maximum score count their raw score.
+func AdjustedGrossScore(holes []HoleScore, maxScores []MaxHoleScore) int {
+	limits := make(map[int]int, len(maxScores))
+	for _, m := range maxScores {
+		limits[m.HoleNumber] = m.MaxScore
+	}
+
+	var total int
+	for _, h := range holes {
+		score := h.RawScore
+		if limit, ok := limits[h.HoleNumber]; ok && score > limit {
+			score = limit
+		}
+		total += score
+	}
+	return total
+}
+
+// ScoreDifferential calculates the differential of a round from its adjusted gross score, the rating of the tee
+// set played and the playing conditions calculation of the day, rounded to the nearest tenth.
+func ScoreDifferential(adjustedGrossScore int, courseRating float64, slopeRating int, pcc int) float64 {
+	differential := StandardSlopeRating / float64(slopeRating) *
+		(float64(adjustedGrossScore) - courseRating - float64(pcc))
+	return math.Round(differential*10) / 10
+}
+
+// FindDuplicateScore returns the score that matches the submission, or nil if it has not been posted. Scores match
+// when they were played on the same date, course and tee set with the same score on every hole.
+func FindDuplicateScore(scores []Score, submission ScoreSubmission) *Score {
+	courseID := strconv.Itoa(submission.CourseID)
+	teeSetID := strconv.Itoa(submission.TeeSetID)
+	for i, s := range scores {
+		if s.PlayedAt != submission.PlayedAt || s.CourseId != courseID || s.TeeSetId != teeSetID {
+			continue
+		}
+		if sameHoleScores(s.HoleDetails, submission.HoleDetails) {
+			return &scores[i]
+		}
+	}
+	return nil
+}
+
+func sameHoleScores(a, b []HoleScore) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	scores := make(map[int]int, len(a))
+	for _, h := range a {
+		scores[h.HoleNumber] = h.RawScore
+	}
+	for _, h := range b {
+		if score, ok := scores[h.HoleNumber]; !ok || score != h.RawScore {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,37 @@
+package scoreexport
+
+import (
+	"encoding/csv"
+	"io"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+// WriteCSV writes a row for each score, with its statistics flattened into columns.
+func WriteCSV(w io.Writer, scores []ghin.Score) error {
+	return writeCSV(w, roundsTable(scores))
+}
+
+// WriteHolesCSV writes a row for each hole of each score.
+func WriteHolesCSV(w io.Writer, scores []ghin.Score) error {
+	return writeCSV(w, holesTable(scores))
+}
+
+func writeCSV(w io.Writer, t table) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(t.header); err != nil {
+		return errors.Wrap(err, "problem writing csv header")
+	}
+	record := make([]string, len(t.header))
+	for _, row := range t.rows {
+		for i, c := range row {
+			record[i] = c.value
+		}
+		if err := writer.Write(record); err != nil {
+			return errors.Wrap(err, "problem writing csv row")
+		}
+	}
+	writer.Flush()
+	return errors.Wrap(writer.Error(), "problem writing csv")
+}
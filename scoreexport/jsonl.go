@@ -0,0 +1,20 @@
+package scoreexport
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+// WriteJSONLines writes each score, including its hole details and statistics, as a JSON object on its own line.
+func WriteJSONLines(w io.Writer, scores []ghin.Score) error {
+	encoder := json.NewEncoder(w)
+	for _, s := range scores {
+		if err := encoder.Encode(s); err != nil {
+			return errors.Wrapf(err, "problem writing score %d", s.Id)
+		}
+	}
+	return nil
+}
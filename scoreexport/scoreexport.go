@@ -0,0 +1,177 @@
+// Package scoreexport writes score histories to CSV, JSON Lines and Excel files for analysis outside of GHIN.
+package scoreexport
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/C-Deck/ghin"
+)
+
+// cell is a single value of a table. Numeric cells are written as numbers where the format supports it.
+type cell struct {
+	value   string
+	numeric bool
+}
+
+// table is a header row followed by rows of cells, shared by the CSV and Excel exporters.
+type table struct {
+	header []string
+	rows   [][]cell
+}
+
+// roundsHeader lists the columns of a score followed by a column for every field of its statistics.
+var roundsHeader = []string{
+	"score_id", "played_at", "posted_at", "course_id", "course_name", "facility_name", "tee_name", "tee_set_id",
+	"tee_set_side", "number_of_holes", "score_type", "status", "adjusted_gross_score", "course_rating",
+	"slope_rating", "pcc", "differential", "unadjusted_differential", "exceptional", "used", "edited", "gir_percent",
+	"putts_total", "one_putt_or_better_percent", "two_putt_percent", "three_putt_or_worse_percent",
+	"two_putt_or_better_percent", "up_and_downs_total", "pars_percent", "par3s_average", "par4s_average",
+	"par5s_average", "bogeys_percent", "missed_left_percent", "missed_long_percent", "fairway_hits_percent",
+	"missed_right_percent", "missed_short_percent", "double_bogeys_percent", "birdies_or_better_percent",
+	"triple_bogeys_or_worse_percent", "missed_left_approach_shot_accuracy_percent",
+	"missed_right_approach_shot_accuracy_percent", "missed_long_approach_shot_accuracy_percent",
+	"missed_short_approach_shot_accuracy_percent", "missed_general_approach_shot_accuracy_percent",
+}
+
+var holesHeader = []string{
+	"score_id", "played_at", "course_name", "hole_number", "par", "raw_score", "putts", "fairway_hit",
+	"gir", "drive_accuracy", "approach_shot_accuracy",
+}
+
+// roundsTable builds a row for each score with its statistics flattened into columns.
+func roundsTable(scores []ghin.Score) table {
+	out := table{header: roundsHeader}
+	for _, s := range scores {
+		row := []cell{
+			intCell(s.Id),
+			stringCell(s.PlayedAt),
+			timeCell(s.PostedAt),
+			stringCell(s.CourseId),
+			stringCell(s.CourseName),
+			optionalStringCell(s.FacilityName),
+			optionalStringCell(s.TeeName),
+			stringCell(s.TeeSetId),
+			stringCell(string(s.TeeSetSide)),
+			intCell(int(s.NumberOfHoles)),
+			stringCell(string(s.ScoreType)),
+			stringCell(string(s.Status)),
+			intCell(s.AdjustedGrossScore),
+			floatCell(s.CourseRating),
+			intCell(s.SlopeRating),
+			optionalIntCell(s.Pcc),
+			floatCell(s.Differential),
+			floatCell(s.UnadjustedDifferential),
+			boolCell(s.Exceptional),
+			boolCell(s.Used),
+			boolCell(s.Edited),
+		}
+		if stats := s.Statistics; stats != nil {
+			row = append(row,
+				intCell(stats.GirPercent),
+				intCell(stats.PuttsTotal),
+				floatCell(stats.OnePuttOrBetterPercent),
+				floatCell(stats.TwoPuttPercent),
+				floatCell(stats.ThreePuttOrWorsePercent),
+				floatCell(stats.TwoPuttOrBetterPercent),
+				intCell(stats.UpAndDownsTotal),
+				floatCell(stats.ParsPercent),
+				floatCell(stats.Par3SAverage),
+				floatCell(stats.Par4SAverage),
+				floatCell(stats.Par5SAverage),
+				floatCell(stats.BogeysPercent),
+				intCell(stats.MissedLeftPercent),
+				intCell(stats.MissedLongPercent),
+				intCell(stats.FairwayHitsPercent),
+				intCell(stats.MissedRightPercent),
+				intCell(stats.MissedShortPercent),
+				floatCell(stats.DoubleBogeysPercent),
+				intCell(stats.BirdiesOrBetterPercent),
+				floatCell(stats.TripleBogeysOrWorsePercent),
+				intCell(stats.MissedLeftApproachShotAccuracyPercent),
+				intCell(stats.MissedRightApproachShotAccuracyPercent),
+				intCell(stats.MissedLongApproachShotAccuracyPercent),
+				intCell(stats.MissedShortApproachShotAccuracyPercent),
+				intCell(stats.MissedGeneralApproachShotAccuracyPercent),
+			)
+		} else {
+			row = append(row, make([]cell, len(roundsHeader)-len(row))...)
+		}
+		out.rows = append(out.rows, row)
+	}
+	return out
+}
+
+// holesTable builds a row for each hole of each score.
+func holesTable(scores []ghin.Score) table {
+	out := table{header: holesHeader}
+	for _, s := range scores {
+		for _, h := range s.HoleDetails {
+			out.rows = append(out.rows, []cell{
+				intCell(s.Id),
+				stringCell(s.PlayedAt),
+				stringCell(s.CourseName),
+				intCell(h.HoleNumber),
+				intCell(h.Par),
+				intCell(h.RawScore),
+				optionalIntCell(h.Putts),
+				optionalBoolCell(h.FairwayHit),
+				optionalBoolCell(h.GreenInRegulation),
+				accuracyCell(h.DriveAccuracy),
+				accuracyCell(h.ApproachShotAccuracy),
+			})
+		}
+	}
+	return out
+}
+
+func stringCell(v string) cell {
+	return cell{value: v}
+}
+
+func optionalStringCell(v *string) cell {
+	if v == nil {
+		return cell{}
+	}
+	return stringCell(*v)
+}
+
+func timeCell(v time.Time) cell {
+	if v.IsZero() {
+		return cell{}
+	}
+	return stringCell(v.Format(time.RFC3339))
+}
+
+func intCell(v int) cell {
+	return cell{value: strconv.Itoa(v), numeric: true}
+}
+
+func optionalIntCell(v *int) cell {
+	if v == nil {
+		return cell{}
+	}
+	return intCell(*v)
+}
+
+func floatCell(v float64) cell {
+	return cell{value: strconv.FormatFloat(v, 'f', -1, 64), numeric: true}
+}
+
+func boolCell(v bool) cell {
+	return cell{value: strconv.FormatBool(v)}
+}
+
+func optionalBoolCell(v *bool) cell {
+	if v == nil {
+		return cell{}
+	}
+	return boolCell(*v)
+}
+
+func accuracyCell(v *ghin.ShotAccuracy) cell {
+	if v == nil {
+		return cell{}
+	}
+	return stringCell(v.String())
+}
@@ -0,0 +1,154 @@
+package scoreexport
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">
+<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>
+<Default Extension="xml" ContentType="application/xml"/>
+<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>
+%s</Types>`
+	xlsxSheetContentType = `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>
+`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>
+</Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">
+<sheets>
+%s</sheets>
+</workbook>`
+	xlsxWorkbookSheet = `<sheet name="%s" sheetId="%d" r:id="rId%d"/>
+`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">
+%s</Relationships>`
+	xlsxWorkbookSheetRel = `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>
+`
+	xlsxSheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<sheetData>
+`
+	xlsxSheetEnd = `</sheetData>
+</worksheet>`
+)
+
+type sheet struct {
+	name  string
+	table table
+}
+
+// WriteXLSX writes an Excel workbook with a Rounds sheet holding a row for each score and a Holes sheet holding a
+// row for each hole of each score.
+func WriteXLSX(w io.Writer, scores []ghin.Score) error {
+	return writeXLSX(w, []sheet{
+		{name: "Rounds", table: roundsTable(scores)},
+		{name: "Holes", table: holesTable(scores)},
+	})
+}
+
+func writeXLSX(w io.Writer, sheets []sheet) error {
+	var contentTypes, workbookSheets, workbookRels strings.Builder
+	for i, s := range sheets {
+		id := i + 1
+		fmt.Fprintf(&contentTypes, xlsxSheetContentType, id)
+		fmt.Fprintf(&workbookSheets, xlsxWorkbookSheet, escapeXML(s.name), id, id)
+		fmt.Fprintf(&workbookRels, xlsxWorkbookSheetRel, id, id)
+	}
+
+	archive := zip.NewWriter(w)
+	files := []struct {
+		name    string
+		content string
+	}{
+		{name: "[Content_Types].xml", content: fmt.Sprintf(xlsxContentTypes, contentTypes.String())},
+		{name: "_rels/.rels", content: xlsxRootRels},
+		{name: "xl/workbook.xml", content: fmt.Sprintf(xlsxWorkbook, workbookSheets.String())},
+		{name: "xl/_rels/workbook.xml.rels", content: fmt.Sprintf(xlsxWorkbookRels, workbookRels.String())},
+	}
+	for _, f := range files {
+		if err := writeZipFile(archive, f.name, f.content); err != nil {
+			return err
+		}
+	}
+	for i, s := range sheets {
+		if err := writeZipFile(archive, fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheetXML(s.table)); err != nil {
+			return err
+		}
+	}
+
+	return errors.Wrap(archive.Close(), "problem writing xlsx archive")
+}
+
+func writeZipFile(archive *zip.Writer, name string, content string) error {
+	f, err := archive.Create(name)
+	if err != nil {
+		return errors.Wrapf(err, "problem creating %s in xlsx archive", name)
+	}
+	if _, err := io.WriteString(f, content); err != nil {
+		return errors.Wrapf(err, "problem writing %s in xlsx archive", name)
+	}
+	return nil
+}
+
+// sheetXML renders a table as worksheet XML. Text is written as inline strings so that the workbook does not need
+// a shared strings part.
+func sheetXML(t table) string {
+	var b strings.Builder
+	b.WriteString(xlsxSheetStart)
+
+	header := make([]cell, len(t.header))
+	for i, h := range t.header {
+		header[i] = stringCell(h)
+	}
+	writeRow(&b, 1, header)
+	for i, row := range t.rows {
+		writeRow(&b, i+2, row)
+	}
+
+	b.WriteString(xlsxSheetEnd)
+	return b.String()
+}
+
+func writeRow(b *strings.Builder, number int, row []cell) {
+	fmt.Fprintf(b, `<row r="%d">`, number)
+	for i, c := range row {
+		if c.value == "" {
+			continue
+		}
+		ref := columnName(i) + strconv.Itoa(number)
+		if c.numeric {
+			fmt.Fprintf(b, `<c r="%s"><v>%s</v></c>`, ref, c.value)
+		} else {
+			fmt.Fprintf(b, `<c r="%s" t="inlineStr"><is><t>%s</t></is></c>`, ref, escapeXML(c.value))
+		}
+	}
+	b.WriteString("</row>\n")
+}
+
+// columnName converts a zero based column index to its spreadsheet name (A, B, ..., Z, AA, ...).
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
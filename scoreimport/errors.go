@@ -0,0 +1,23 @@
+package scoreimport
+
+import (
+	"fmt"
+)
+
+// RowError describes why a row of a CSV file could not be imported.
+type RowError struct {
+	Row    int
+	Column string
+	Msg    string
+}
+
+func newRowError(row int, column string, format string, args ...any) *RowError {
+	return &RowError{Row: row, Column: column, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (e RowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("row %d: %s", e.Row, e.Msg)
+	}
+	return fmt.Sprintf("row %d, column %q: %s", e.Row, e.Column, e.Msg)
+}
@@ -0,0 +1,132 @@
+package scoreimport
+
+import (
+	"context"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/C-Deck/ghin"
+)
+
+// CourseResolver looks up courses by name. It is implemented by *ghin.Client.
+type CourseResolver interface {
+	SearchCourses(ctx context.Context, input ghin.SearchCoursesInput) ([]ghin.CourseOverview, error)
+	GetCourseDetails(ctx context.Context, input ghin.GetCourseDetailsInput) (*ghin.CourseDetails, error)
+}
+
+// Resolve fills in the course and tee set IDs of rounds that named their course or tee set. Course names must match
+// exactly one course, ignoring case, and tee names must match a tee set rated for the golfer's gender. Rounds that
+// cannot be resolved are reported as RowErrors and left out of the result.
+func Resolve(ctx context.Context, resolver CourseResolver, rounds []Round) ([]Round, []RowError, error) {
+	r := courseResolver{
+		resolver:  resolver,
+		courseIDs: map[string]int{},
+		courses:   map[int]*ghin.CourseDetails{},
+	}
+
+	var out []Round
+	var rowErrs []RowError
+	for _, round := range rounds {
+		rowErr, err := r.resolve(ctx, &round)
+		if err != nil {
+			return nil, nil, err
+		}
+		if rowErr != nil {
+			rowErrs = append(rowErrs, *rowErr)
+			continue
+		}
+		out = append(out, round)
+	}
+	return out, rowErrs, nil
+}
+
+// Import reads the rounds of a CSV file and resolves their courses and tee sets.
+func Import(ctx context.Context, resolver CourseResolver, r io.Reader, opts Options) ([]Round, []RowError, error) {
+	rounds, readErrs, err := Read(r, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+	resolved, resolveErrs, err := Resolve(ctx, resolver, rounds)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resolved, append(readErrs, resolveErrs...), nil
+}
+
+// courseResolver caches the courses it looks up since imported rounds are mostly played on a few courses.
+type courseResolver struct {
+	resolver  CourseResolver
+	courseIDs map[string]int
+	courses   map[int]*ghin.CourseDetails
+}
+
+// resolve fills in the IDs of the round. Problems with the round are returned as a RowError while the error is
+// reserved for failed requests.
+func (r *courseResolver) resolve(ctx context.Context, round *Round) (*RowError, error) {
+	if round.CourseName != "" {
+		id, rowErr, err := r.courseID(ctx, round)
+		if err != nil || rowErr != nil {
+			return rowErr, err
+		}
+		round.Input.CourseID = id
+		round.CourseName = ""
+	}
+	if round.TeeName == "" {
+		return nil, nil
+	}
+
+	course, err := r.course(ctx, round.Input.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	gender := round.Input.Gender.LongString()
+	for _, tee := range course.TeeSets {
+		if strings.EqualFold(tee.TeeSetRatingName, round.TeeName) && string(tee.Gender) == gender {
+			round.Input.TeeSetID = tee.TeeSetRatingId
+			round.TeeName = ""
+			return nil, nil
+		}
+	}
+	return newRowError(round.Row, "", "course %q has no %s tee set named %q", course.CourseName, gender,
+		round.TeeName), nil
+}
+
+func (r *courseResolver) courseID(ctx context.Context, round *Round) (int, *RowError, error) {
+	key := strings.ToLower(round.CourseName)
+	if id, ok := r.courseIDs[key]; ok {
+		return id, nil, nil
+	}
+
+	courses, err := r.resolver.SearchCourses(ctx, ghin.SearchCoursesInput{CourseName: &round.CourseName})
+	if err != nil {
+		return 0, nil, err
+	}
+	var matches []ghin.CourseOverview
+	for _, c := range courses {
+		if strings.EqualFold(c.CourseName, round.CourseName) || strings.EqualFold(c.FullName, round.CourseName) {
+			matches = append(matches, c)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return 0, newRowError(round.Row, "", "no course named %q", round.CourseName), nil
+	case 1:
+		r.courseIDs[key] = matches[0].CourseID
+		return matches[0].CourseID, nil, nil
+	}
+	return 0, newRowError(round.Row, "", "%d courses are named %q, provide the course ID instead", len(matches),
+		round.CourseName), nil
+}
+
+func (r *courseResolver) course(ctx context.Context, id int) (*ghin.CourseDetails, error) {
+	if course, ok := r.courses[id]; ok {
+		return course, nil
+	}
+	course, err := r.resolver.GetCourseDetails(ctx, ghin.GetCourseDetailsInput{CourseID: strconv.Itoa(id)})
+	if err != nil {
+		return nil, err
+	}
+	r.courses[id] = course
+	return course, nil
+}
@@ -0,0 +1,458 @@
+// Package scoreimport reads rounds kept in CSV files, such as scorekeeping spreadsheets, into score submissions.
+package scoreimport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+// Layout represents how rounds are laid out in the rows of a CSV file.
+type Layout string
+
+const (
+	// LayoutRound has one row per round with a column for the score of each hole.
+	LayoutRound Layout = "Round"
+	// LayoutHole has one row per hole played. Rows with the same date, course, tee and round number make a round.
+	LayoutHole Layout = "Hole"
+
+	defaultDateFormat = "2006-01-02"
+)
+
+// Columns maps the fields of a round to the headers of the CSV columns that hold them. Columns left empty are not
+// read.
+type Columns struct {
+	Date      string
+	Course    string
+	CourseID  string
+	Tee       string
+	TeeSetID  string
+	Gender    string
+	Side      string
+	ScoreType string
+	// Round distinguishes rounds played on the same date, course and tee in the LayoutHole layout.
+	Round string
+
+	// Hole is the hole number in the LayoutHole layout.
+	Hole string
+	// Score, Putts, FairwayHit and GreenInRegulation are the statistics of the hole in the LayoutHole layout.
+	Score             string
+	Putts             string
+	FairwayHit        string
+	GreenInRegulation string
+
+	// HoleScore is a format with a single %d verb for the hole number, giving the columns of the hole scores in the
+	// LayoutRound layout.
+	HoleScore string
+}
+
+// DefaultColumns are the column headers used when Options.Columns is not provided.
+var DefaultColumns = Columns{
+	Date:              "Date",
+	Course:            "Course",
+	CourseID:          "Course ID",
+	Tee:               "Tee",
+	TeeSetID:          "Tee Set ID",
+	Gender:            "Gender",
+	Side:              "Side",
+	ScoreType:         "Score Type",
+	Round:             "Round",
+	Hole:              "Hole",
+	Score:             "Score",
+	Putts:             "Putts",
+	FairwayHit:        "Fairway",
+	GreenInRegulation: "GIR",
+	HoleScore:         "Hole %d",
+}
+
+type Options struct {
+	/* Layout is how rounds are laid out in the rows.
+	 * Default: LayoutRound
+	 */
+	Layout *Layout
+
+	/* Columns maps the fields of a round to column headers.
+	 * Default: DefaultColumns
+	 */
+	Columns *Columns
+
+	/* DateFormat is the layout of the dates in the Date column, as understood by time.Parse.
+	 * Default: 2006-01-02
+	 */
+	DateFormat *string
+
+	/* Comma is the field delimiter.
+	 * Default: ','
+	 */
+	Comma *rune
+
+	/* Gender is the gender of the golfer for rows without a Gender column.
+	 * Default: Male
+	 */
+	Gender *ghin.PlayerGender
+}
+
+// Round is a round read from a CSV file. Rounds whose course or tee set was given by name need to be resolved
+// before Input can be submitted.
+type Round struct {
+	// Row is the first row of the file the round was read from. The header is row 1.
+	Row int
+	// CourseName is the name of the course if the file did not provide its ID.
+	CourseName string
+	// TeeName is the name of the tee set if the file did not provide its ID.
+	TeeName string
+	// Input is the score submission for the round.
+	Input ghin.SubmitScoreInput
+}
+
+// Read reads the rounds of a CSV file. Rows that cannot be read, including malformed CSV rows, are reported as
+// RowErrors in row order and skipped, while an error is returned only when the file itself cannot be read.
+func Read(r io.Reader, opts Options) ([]Round, []RowError, error) {
+	layout := LayoutRound
+	if opts.Layout != nil {
+		layout = *opts.Layout
+	}
+	p := parser{columns: DefaultColumns, dateFormat: defaultDateFormat, gender: ghin.PlayerGenderMale}
+	if opts.Columns != nil {
+		p.columns = *opts.Columns
+	}
+	if opts.DateFormat != nil {
+		p.dateFormat = *opts.DateFormat
+	}
+	if opts.Gender != nil {
+		p.gender = *opts.Gender
+	}
+
+	reader := csv.NewReader(r)
+	if opts.Comma != nil {
+		reader.Comma = *opts.Comma
+	}
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "problem reading csv header")
+	}
+	p.index = make(map[string]int, len(header))
+	for i, h := range header {
+		p.index[strings.TrimSpace(h)] = i
+	}
+
+	var rows []row
+	var parseErrs []RowError
+	for number := 2; ; number++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			parseErrs = append(parseErrs, *newRowError(number, "", "%s", parseErr.Err))
+			continue
+		}
+		if err != nil {
+			return nil, nil, errors.Wrapf(err, "problem reading csv row %d", number)
+		}
+		rows = append(rows, row{number: number, record: record})
+	}
+
+	var rounds []Round
+	var rowErrs []RowError
+	switch layout {
+	case LayoutRound:
+		rounds, rowErrs = p.readRounds(rows)
+	case LayoutHole:
+		rounds, rowErrs = p.readHoles(rows)
+	default:
+		return nil, nil, errors.Errorf("unknown layout %q", layout)
+	}
+	rowErrs = append(parseErrs, rowErrs...)
+	sort.SliceStable(rowErrs, func(i, j int) bool {
+		return rowErrs[i].Row < rowErrs[j].Row
+	})
+	return rounds, rowErrs, nil
+}
+
+type row struct {
+	number int
+	record []string
+}
+
+type parser struct {
+	columns    Columns
+	index      map[string]int
+	dateFormat string
+	gender     ghin.PlayerGender
+}
+
+// value returns the trimmed value of the column in the row, or an empty string if the column is not in the file.
+func (p *parser) value(r row, column string) string {
+	i, ok := p.index[column]
+	if column == "" || !ok || i >= len(r.record) {
+		return ""
+	}
+	return strings.TrimSpace(r.record[i])
+}
+
+// readRound reads the fields that describe the round rather than a hole.
+func (p *parser) readRound(r row) (Round, *RowError) {
+	out := Round{
+		Row:        r.number,
+		CourseName: p.value(r, p.columns.Course),
+		TeeName:    p.value(r, p.columns.Tee),
+		Input:      ghin.SubmitScoreInput{Gender: p.gender},
+	}
+
+	date := p.value(r, p.columns.Date)
+	playedAt, err := time.Parse(p.dateFormat, date)
+	if err != nil {
+		return out, newRowError(r.number, p.columns.Date, "invalid date %q", date)
+	}
+	out.Input.PlayedAt = &playedAt
+
+	if v := p.value(r, p.columns.CourseID); v != "" {
+		if out.Input.CourseID, err = strconv.Atoi(v); err != nil {
+			return out, newRowError(r.number, p.columns.CourseID, "invalid course ID %q", v)
+		}
+		out.CourseName = ""
+	} else if out.CourseName == "" {
+		return out, newRowError(r.number, p.columns.Course, "course is required")
+	}
+	if v := p.value(r, p.columns.TeeSetID); v != "" {
+		if out.Input.TeeSetID, err = strconv.Atoi(v); err != nil {
+			return out, newRowError(r.number, p.columns.TeeSetID, "invalid tee set ID %q", v)
+		}
+		out.TeeName = ""
+	} else if out.TeeName == "" {
+		return out, newRowError(r.number, p.columns.Tee, "tee is required")
+	}
+
+	if v := p.value(r, p.columns.Gender); v != "" {
+		gender, ok := parseGender(v)
+		if !ok {
+			return out, newRowError(r.number, p.columns.Gender, "invalid gender %q", v)
+		}
+		out.Input.Gender = gender
+	}
+	if v := p.value(r, p.columns.Side); v != "" {
+		side, ok := parseSide(v)
+		if !ok {
+			return out, newRowError(r.number, p.columns.Side, "invalid tee set side %q", v)
+		}
+		out.Input.TeeSetSide = &side
+	}
+	if v := p.value(r, p.columns.ScoreType); v != "" {
+		scoreType, ok := parseScoreType(v)
+		if !ok {
+			return out, newRowError(r.number, p.columns.ScoreType, "invalid score type %q", v)
+		}
+		out.Input.ScoreType = &scoreType
+	}
+
+	return out, nil
+}
+
+func (p *parser) readRounds(rows []row) ([]Round, []RowError) {
+	var out []Round
+	var rowErrs []RowError
+	for _, r := range rows {
+		round, rowErr := p.readRound(r)
+		if rowErr != nil {
+			rowErrs = append(rowErrs, *rowErr)
+			continue
+		}
+
+		for hole := 1; hole <= int(ghin.EighteenHolesPlayed); hole++ {
+			column := fmt.Sprintf(p.columns.HoleScore, hole)
+			v := p.value(r, column)
+			if v == "" {
+				continue
+			}
+			score, err := strconv.Atoi(v)
+			if err != nil {
+				rowErr = newRowError(r.number, column, "invalid score %q", v)
+				break
+			}
+			round.Input.HoleDetails = append(round.Input.HoleDetails, ghin.HoleScore{HoleNumber: hole, RawScore: score})
+		}
+		if rowErr == nil {
+			rowErr = completeRound(&round, p.columns.HoleScore)
+		}
+		if rowErr != nil {
+			rowErrs = append(rowErrs, *rowErr)
+			continue
+		}
+		out = append(out, round)
+	}
+	return out, rowErrs
+}
+
+func (p *parser) readHoles(rows []row) ([]Round, []RowError) {
+	var keys []string
+	rounds := map[string]*Round{}
+	failed := map[string]bool{}
+	var rowErrs []RowError
+	for _, r := range rows {
+		key := strings.Join([]string{
+			p.value(r, p.columns.Date),
+			p.value(r, p.columns.CourseID),
+			p.value(r, p.columns.Course),
+			p.value(r, p.columns.TeeSetID),
+			p.value(r, p.columns.Tee),
+			p.value(r, p.columns.Round),
+		}, "\x00")
+		if failed[key] {
+			continue
+		}
+
+		round, ok := rounds[key]
+		if !ok {
+			read, rowErr := p.readRound(r)
+			if rowErr != nil {
+				rowErrs = append(rowErrs, *rowErr)
+				failed[key] = true
+				continue
+			}
+			round = &read
+			rounds[key] = round
+			keys = append(keys, key)
+		}
+
+		hole, rowErr := p.readHole(r)
+		if rowErr != nil {
+			rowErrs = append(rowErrs, *rowErr)
+			failed[key] = true
+			continue
+		}
+		round.Input.HoleDetails = append(round.Input.HoleDetails, hole)
+	}
+
+	var out []Round
+	for _, key := range keys {
+		if failed[key] {
+			continue
+		}
+		round := rounds[key]
+		if rowErr := completeRound(round, p.columns.Hole); rowErr != nil {
+			rowErrs = append(rowErrs, *rowErr)
+			continue
+		}
+		out = append(out, *round)
+	}
+	return out, rowErrs
+}
+
+func (p *parser) readHole(r row) (ghin.HoleScore, *RowError) {
+	var out ghin.HoleScore
+	var err error
+
+	v := p.value(r, p.columns.Hole)
+	if out.HoleNumber, err = strconv.Atoi(v); err != nil {
+		return out, newRowError(r.number, p.columns.Hole, "invalid hole number %q", v)
+	}
+	v = p.value(r, p.columns.Score)
+	if out.RawScore, err = strconv.Atoi(v); err != nil {
+		return out, newRowError(r.number, p.columns.Score, "invalid score %q", v)
+	}
+	if v := p.value(r, p.columns.Putts); v != "" {
+		putts, err := strconv.Atoi(v)
+		if err != nil {
+			return out, newRowError(r.number, p.columns.Putts, "invalid putts %q", v)
+		}
+		out.Putts = &putts
+	}
+	if v := p.value(r, p.columns.FairwayHit); v != "" {
+		hit, err := parseBool(v)
+		if err != nil {
+			return out, newRowError(r.number, p.columns.FairwayHit, "invalid fairway hit %q", v)
+		}
+		out.FairwayHit = &hit
+	}
+	if v := p.value(r, p.columns.GreenInRegulation); v != "" {
+		hit, err := parseBool(v)
+		if err != nil {
+			return out, newRowError(r.number, p.columns.GreenInRegulation, "invalid green in regulation %q", v)
+		}
+		out.GreenInRegulation = &hit
+	}
+
+	return out, nil
+}
+
+// completeRound works out the number of holes and the side played from the hole scores when they were not given.
+func completeRound(round *Round, column string) *RowError {
+	var holes ghin.HolesPlayed
+	switch len(round.Input.HoleDetails) {
+	case int(ghin.EighteenHolesPlayed):
+		holes = ghin.EighteenHolesPlayed
+	case int(ghin.NineHolesPlayed):
+		holes = ghin.NineHolesPlayed
+	default:
+		return newRowError(round.Row, column, "expected 9 or 18 hole scores, got %d", len(round.Input.HoleDetails))
+	}
+	round.Input.NumberOfHoles = &holes
+
+	if round.Input.TeeSetSide == nil {
+		side := ghin.TeeSetSide18
+		if holes == ghin.NineHolesPlayed {
+			side = ghin.TeeSetSideFront
+			if round.Input.HoleDetails[0].HoleNumber > int(ghin.NineHolesPlayed) {
+				side = ghin.TeeSetSideBack
+			}
+		}
+		round.Input.TeeSetSide = &side
+	}
+	return nil
+}
+
+func parseGender(v string) (ghin.PlayerGender, bool) {
+	switch strings.ToUpper(v) {
+	case "M", "MALE":
+		return ghin.PlayerGenderMale, true
+	case "F", "FEMALE":
+		return ghin.PlayerGenderFemale, true
+	}
+	return "", false
+}
+
+func parseSide(v string) (ghin.TeeSetSide, bool) {
+	switch strings.ToUpper(v) {
+	case strings.ToUpper(string(ghin.TeeSetSide18)), "18":
+		return ghin.TeeSetSide18, true
+	case string(ghin.TeeSetSideFront), "FRONT":
+		return ghin.TeeSetSideFront, true
+	case string(ghin.TeeSetSideBack), "BACK":
+		return ghin.TeeSetSideBack, true
+	}
+	return "", false
+}
+
+func parseScoreType(v string) (ghin.ScoringType, bool) {
+	switch strings.ToUpper(v) {
+	case ghin.ScoringTypeHome, "HOME":
+		return ghin.ScoringTypeHome, true
+	case ghin.ScoringTypeAway, "AWAY":
+		return ghin.ScoringTypeAway, true
+	case ghin.ScoringTypeTournament, "TOURNAMENT":
+		return ghin.ScoringTypeTournament, true
+	}
+	return "", false
+}
+
+func parseBool(v string) (bool, error) {
+	switch strings.ToUpper(v) {
+	case "Y", "YES":
+		return true, nil
+	case "N", "NO":
+		return false, nil
+	}
+	return strconv.ParseBool(v)
+}
@@ -0,0 +1,191 @@
+package scoreimport
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/C-Deck/ghin"
+)
+
+// roundRow returns a row of the LayoutRound layout with a score of 4 on each of the holes.
+func roundRow(date, course, tee string, holes int) string {
+	scores := make([]string, holes)
+	for i := range scores {
+		scores[i] = "4"
+	}
+	return strings.Join(append([]string{date, course, tee}, scores...), ",")
+}
+
+// roundHeader is the header of the LayoutRound layout for the holes.
+func roundHeader(holes int) string {
+	columns := []string{"Date", "Course", "Tee"}
+	for i := 1; i <= holes; i++ {
+		columns = append(columns, fmt.Sprintf("Hole %d", i))
+	}
+	return strings.Join(columns, ",")
+}
+
+func TestReadRoundLayout(t *testing.T) {
+	file := strings.Join([]string{
+		roundHeader(18),
+		roundRow("2024-06-01", "Pine Valley", "Blue", 18),
+		roundRow("2024-06-02", "Pine Valley", "Blue", 9),
+		roundRow("June 3", "Pine Valley", "Blue", 18),
+		roundRow("2024-06-04", "", "Blue", 18),
+		roundRow("2024-06-05", "Pine Valley", "Blue", 12),
+		`2024-06-06,"Pine" Valley,Blue`,
+		roundRow("2024-06-07", "Pine Valley", "Blue", 18),
+	}, "\n")
+
+	rounds, rowErrs, err := Read(strings.NewReader(file), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var rows []int
+	for _, r := range rounds {
+		rows = append(rows, r.Row)
+		if r.CourseName != "Pine Valley" || r.TeeName != "Blue" || r.Input.Gender != ghin.PlayerGenderMale {
+			t.Errorf("round of row %d = %+v, want Pine Valley from the Blue tees", r.Row, r)
+		}
+	}
+	if want := []int{2, 3, 8}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("rounds read from rows %v, want %v", rows, want)
+	}
+	if side := rounds[1].Input.TeeSetSide; side == nil || *side != ghin.TeeSetSideFront ||
+		*rounds[1].Input.NumberOfHoles != ghin.NineHolesPlayed {
+		t.Errorf("9 hole round = %+v, want the front nine", rounds[1].Input)
+	}
+
+	want := []struct {
+		row    int
+		column string
+	}{
+		{row: 4, column: "Date"},
+		{row: 5, column: "Course"},
+		{row: 6, column: "Hole %d"},
+		{row: 7, column: ""},
+	}
+	if len(rowErrs) != len(want) {
+		t.Fatalf("row errors = %v, want %d", rowErrs, len(want))
+	}
+	for i, w := range want {
+		if rowErrs[i].Row != w.row || rowErrs[i].Column != w.column {
+			t.Errorf("row error %d = %v, want row %d column %q", i, rowErrs[i], w.row, w.column)
+		}
+	}
+}
+
+func TestReadHoleLayout(t *testing.T) {
+	rows := []string{"Date,Course ID,Tee Set ID,Round,Hole,Score,Putts,Fairway,GIR"}
+	for hole := 10; hole <= 18; hole++ {
+		rows = append(rows, fmt.Sprintf("2024-06-01,100,200,1,%d,5,2,Y,N", hole))
+	}
+	for hole := 1; hole <= 9; hole++ {
+		rows = append(rows, fmt.Sprintf("2024-06-01,100,200,2,%d,4,,,", hole))
+	}
+	rows = append(rows, "2024-06-01,100,200,3,1,x,,,")
+
+	layout := LayoutHole
+	rounds, rowErrs, err := Read(strings.NewReader(strings.Join(rows, "\n")), Options{Layout: &layout})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rounds) != 2 {
+		t.Fatalf("rounds = %+v, want 2", rounds)
+	}
+
+	first := rounds[0]
+	if first.Row != 2 || first.Input.CourseID != 100 || first.Input.TeeSetID != 200 ||
+		*first.Input.TeeSetSide != ghin.TeeSetSideBack || len(first.Input.HoleDetails) != 9 {
+		t.Errorf("first round = %+v, want 9 holes on the back of tee set 200", first)
+	}
+	hole := first.Input.HoleDetails[0]
+	if hole.HoleNumber != 10 || hole.RawScore != 5 || *hole.Putts != 2 || !*hole.FairwayHit ||
+		*hole.GreenInRegulation {
+		t.Errorf("first hole = %+v, want 5 on hole 10 with 2 putts and the fairway hit", hole)
+	}
+	if second := rounds[1]; second.Row != 11 || *second.Input.TeeSetSide != ghin.TeeSetSideFront {
+		t.Errorf("second round = %+v, want the front nine from row 11", second)
+	}
+
+	if len(rowErrs) != 1 || rowErrs[0].Row != 20 || rowErrs[0].Column != "Score" {
+		t.Errorf("row errors = %v, want an invalid score on row 20", rowErrs)
+	}
+}
+
+// fakeResolver is a CourseResolver over a fixed set of courses.
+type fakeResolver struct {
+	courses  []ghin.CourseDetails
+	searches int
+}
+
+func (f *fakeResolver) SearchCourses(_ context.Context, input ghin.SearchCoursesInput) ([]ghin.CourseOverview, error) {
+	f.searches++
+	var out []ghin.CourseOverview
+	for _, c := range f.courses {
+		if strings.Contains(strings.ToLower(c.CourseName), strings.ToLower(*input.CourseName)) {
+			out = append(out, ghin.CourseOverview{CourseID: c.CourseId, CourseName: c.CourseName})
+		}
+	}
+	return out, nil
+}
+
+func (f *fakeResolver) GetCourseDetails(_ context.Context, input ghin.GetCourseDetailsInput) (*ghin.CourseDetails,
+	error) {
+	for i, c := range f.courses {
+		if fmt.Sprint(c.CourseId) == input.CourseID {
+			return &f.courses[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no course %s", input.CourseID)
+}
+
+func TestResolve(t *testing.T) {
+	resolver := &fakeResolver{courses: []ghin.CourseDetails{
+		{CourseId: 1, CourseName: "Pine Valley", TeeSets: []ghin.TeeSetDetails{
+			{TeeSetRatingId: 10, TeeSetRatingName: "Blue", Gender: ghin.TeeSetGenderFemale},
+			{TeeSetRatingId: 11, TeeSetRatingName: "Blue", Gender: ghin.TeeSetGenderMale},
+		}},
+		{CourseId: 2, CourseName: "Oak Hill East"},
+		{CourseId: 3, CourseName: "Oak Hill West"},
+	}}
+	rounds := []Round{
+		{Row: 2, CourseName: "pine valley", TeeName: "BLUE", Input: ghin.SubmitScoreInput{Gender: ghin.PlayerGenderMale}},
+		{Row: 3, CourseName: "Pine Valley", TeeName: "Blue", Input: ghin.SubmitScoreInput{Gender: ghin.PlayerGenderFemale}},
+		{Row: 4, CourseName: "Pine Valley", TeeName: "Red", Input: ghin.SubmitScoreInput{Gender: ghin.PlayerGenderMale}},
+		{Row: 5, CourseName: "Oak Hill", TeeName: "White", Input: ghin.SubmitScoreInput{Gender: ghin.PlayerGenderMale}},
+		{Row: 6, CourseName: "Augusta", TeeName: "White", Input: ghin.SubmitScoreInput{Gender: ghin.PlayerGenderMale}},
+		{Row: 7, Input: ghin.SubmitScoreInput{CourseID: 9, TeeSetID: 90}},
+	}
+
+	resolved, rowErrs, err := Resolve(context.Background(), resolver, rounds)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var teeSets []int
+	for _, r := range resolved {
+		if r.CourseName != "" || r.TeeName != "" {
+			t.Errorf("round of row %d = %+v, want its names resolved", r.Row, r)
+		}
+		teeSets = append(teeSets, r.Input.TeeSetID)
+	}
+	if want := []int{11, 10, 90}; !reflect.DeepEqual(teeSets, want) {
+		t.Errorf("resolved tee sets = %v, want %v", teeSets, want)
+	}
+	if resolver.searches != 3 {
+		t.Errorf("searched %d times, want each course name searched once", resolver.searches)
+	}
+
+	var rows []int
+	for _, e := range rowErrs {
+		rows = append(rows, e.Row)
+	}
+	if want := []int{4, 5, 6}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("row errors = %v, want rows %v", rowErrs, want)
+	}
+}
@@ -0,0 +1,159 @@
+package ghin
+
+import (
+	"math"
+)
+
+// statisticsCounter accumulates the hole results that make up RoundStatistics.
+type statisticsCounter struct {
+	stats RoundStatistics
+
+	puttHoles, onePutts, twoPutts, threePutts int
+
+	parHoles, birdies, pars, bogeys, doubleBogeys, triples int
+	parTotals, parCounts                                   map[int]int
+
+	greenHoles, greensHit          int
+	approachMisses                 map[ShotAccuracy]int
+	approachMissesWithoutDirection int
+	fairwayHoles, fairwaysHit      int
+	driveMisses                    map[ShotAccuracy]int
+}
+
+// ComputeRoundStatistics calculates the statistics of a round from its hole scores in the same shape GHIN returns
+// them for posted scores. Pars come from the hole scores, or from the tee set when a hole score has no par and tee
+// is provided. Each statistic only considers the holes that recorded it, so rounds with partial statistics are
+// still summarized.
+func ComputeRoundStatistics(holes []HoleScore, tee *TeeSetDetails) *RoundStatistics {
+	c := statisticsCounter{
+		parTotals:      map[int]int{},
+		parCounts:      map[int]int{},
+		approachMisses: map[ShotAccuracy]int{},
+		driveMisses:    map[ShotAccuracy]int{},
+	}
+	for _, h := range holes {
+		par := h.Par
+		if par == 0 && tee != nil {
+			if details := tee.Hole(h.HoleNumber); details != nil {
+				par = details.Par
+			}
+		}
+		c.add(h, par)
+	}
+	return c.result()
+}
+
+func (c *statisticsCounter) add(h HoleScore, par int) {
+	if h.Putts != nil {
+		c.stats.PuttsTotal += *h.Putts
+		c.puttHoles++
+		switch {
+		case *h.Putts <= 1:
+			c.onePutts++
+		case *h.Putts == 2:
+			c.twoPutts++
+		default:
+			c.threePutts++
+		}
+	}
+
+	if par > 0 {
+		c.parHoles++
+		c.parTotals[par] += h.RawScore
+		c.parCounts[par]++
+		switch toPar := h.RawScore - par; {
+		case toPar < 0:
+			c.birdies++
+		case toPar == 0:
+			c.pars++
+		case toPar == 1:
+			c.bogeys++
+		case toPar == 2:
+			c.doubleBogeys++
+		default:
+			c.triples++
+		}
+	}
+
+	if h.GreenInRegulation != nil {
+		c.greenHoles++
+		if *h.GreenInRegulation {
+			c.greensHit++
+		} else {
+			if par > 0 && h.RawScore <= par {
+				c.stats.UpAndDownsTotal++
+			}
+			if h.ApproachShotAccuracy != nil {
+				c.approachMisses[*h.ApproachShotAccuracy]++
+			} else {
+				c.approachMissesWithoutDirection++
+			}
+		}
+	}
+
+	if h.FairwayHit != nil {
+		c.fairwayHoles++
+		if *h.FairwayHit {
+			c.fairwaysHit++
+		} else if h.DriveAccuracy != nil {
+			c.driveMisses[*h.DriveAccuracy]++
+		}
+	}
+}
+
+func (c *statisticsCounter) result() *RoundStatistics {
+	out := c.stats
+
+	out.OnePuttOrBetterPercent = percent(c.onePutts, c.puttHoles)
+	out.TwoPuttPercent = percent(c.twoPutts, c.puttHoles)
+	out.ThreePuttOrWorsePercent = percent(c.threePutts, c.puttHoles)
+	out.TwoPuttOrBetterPercent = percent(c.onePutts+c.twoPutts, c.puttHoles)
+
+	out.BirdiesOrBetterPercent = wholePercent(c.birdies, c.parHoles)
+	out.ParsPercent = percent(c.pars, c.parHoles)
+	out.BogeysPercent = percent(c.bogeys, c.parHoles)
+	out.DoubleBogeysPercent = percent(c.doubleBogeys, c.parHoles)
+	out.TripleBogeysOrWorsePercent = percent(c.triples, c.parHoles)
+	out.Par3SAverage = average(c.parTotals[3], c.parCounts[3])
+	out.Par4SAverage = average(c.parTotals[4], c.parCounts[4])
+	out.Par5SAverage = average(c.parTotals[5], c.parCounts[5])
+
+	out.GirPercent = wholePercent(c.greensHit, c.greenHoles)
+	out.MissedLeftApproachShotAccuracyPercent = wholePercent(c.approachMisses[ShotAccuracyMissedLeft], c.greenHoles)
+	out.MissedRightApproachShotAccuracyPercent = wholePercent(c.approachMisses[ShotAccuracyMissedRight], c.greenHoles)
+	out.MissedLongApproachShotAccuracyPercent = wholePercent(c.approachMisses[ShotAccuracyMissedLong], c.greenHoles)
+	out.MissedShortApproachShotAccuracyPercent = wholePercent(c.approachMisses[ShotAccuracyMissedShort], c.greenHoles)
+	out.MissedGeneralApproachShotAccuracyPercent = wholePercent(c.approachMissesWithoutDirection, c.greenHoles)
+
+	out.FairwayHitsPercent = wholePercent(c.fairwaysHit, c.fairwayHoles)
+	out.MissedLeftPercent = wholePercent(c.driveMisses[ShotAccuracyMissedLeft], c.fairwayHoles)
+	out.MissedRightPercent = wholePercent(c.driveMisses[ShotAccuracyMissedRight], c.fairwayHoles)
+	out.MissedLongPercent = wholePercent(c.driveMisses[ShotAccuracyMissedLong], c.fairwayHoles)
+	out.MissedShortPercent = wholePercent(c.driveMisses[ShotAccuracyMissedShort], c.fairwayHoles)
+
+	return &out
+}
+
+// percent returns count as a percentage of total rounded to two decimals, or zero when total is zero.
+func percent(count, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return math.Round(float64(count)*10000/float64(total)) / 100
+}
+
+// wholePercent returns count as a percentage of total rounded to a whole number, or zero when total is zero.
+func wholePercent(count, total int) int {
+	if total == 0 {
+		return 0
+	}
+	return int(math.Round(float64(count) * 100 / float64(total)))
+}
+
+// average returns total divided by count rounded to two decimals, or zero when count is zero.
+func average(total, count int) float64 {
+	if count == 0 {
+		return 0
+	}
+	return math.Round(float64(total)*100/float64(count)) / 100
+}
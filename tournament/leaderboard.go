@@ -0,0 +1,103 @@
+package tournament
+
+import (
+	"sort"
+
+	"github.com/C-Deck/ghin/formats"
+	"github.com/pkg/errors"
+)
+
+// countbackHoles are the numbers of closing holes compared, in order, to break a tie.
+var countbackHoles = []int{9, 6, 3, 1}
+
+// Standing is the place of an entry on a leaderboard.
+type Standing struct {
+	Entry *Entry
+	// Position is shared by entries that are still tied after the countback.
+	Position int
+	Tied     bool
+	Gross    int
+	Net      int
+	// ToPar is the gross or net score the leaderboard is ranked by relative to the par of the tee set.
+	ToPar int
+
+	// holes are the gross or net scores of the holes ordered by hole number.
+	holes []int
+}
+
+// Score returns the gross or net score the leaderboard is ranked by.
+func (s Standing) Score(scoring formats.Scoring) int {
+	if scoring == formats.ScoringNet {
+		return s.Net
+	}
+	return s.Gross
+}
+
+// Leaderboard ranks the entries of the flight that recorded a score, or of the whole field when flight is empty, from
+// the lowest to the highest gross or net score. Ties are broken by the scores of the last 9, 6, 3 and 1 holes of the
+// tee set. Net scores receive the playing handicap of each entry on the holes allocated by the tee set, so net ties
+// are broken on hole by hole net scores.
+func (e *Event) Leaderboard(scoring formats.Scoring, flight string) ([]Standing, error) {
+	var out []Standing
+	for _, entry := range e.Entries {
+		if entry.Holes == nil || (flight != "" && entry.Flight != flight) {
+			continue
+		}
+		card, err := formats.NewCard(formats.Player{
+			ID:              entry.Golfer.GhinNumber,
+			Name:            entry.Golfer.PlayerName,
+			PlayingHandicap: entry.PlayingHandicap,
+			Holes:           entry.Holes,
+		}, e.Tee)
+		if err != nil {
+			return nil, errors.Wrapf(err, "problem allocating strokes of golfer %s", entry.Golfer.GhinNumber)
+		}
+
+		sort.Slice(card.Holes, func(i, j int) bool {
+			return card.Holes[i].Number < card.Holes[j].Number
+		})
+		standing := Standing{Entry: entry}
+		for _, h := range card.Holes {
+			standing.Gross += h.Gross
+			standing.Net += h.Net()
+			standing.holes = append(standing.holes, h.Score(scoring))
+		}
+		standing.ToPar = standing.Score(scoring) - e.Tee.TotalPar
+		out = append(out, standing)
+	}
+
+	compare := func(a, b Standing) int {
+		if d := a.Score(scoring) - b.Score(scoring); d != 0 {
+			return d
+		}
+		for _, holes := range countbackHoles {
+			if d := closingTotal(a.holes, holes) - closingTotal(b.holes, holes); d != 0 {
+				return d
+			}
+		}
+		return 0
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		return compare(out[i], out[j]) < 0
+	})
+	for i := range out {
+		out[i].Position = i + 1
+		if i > 0 && compare(out[i], out[i-1]) == 0 {
+			out[i].Position = out[i-1].Position
+			out[i].Tied, out[i-1].Tied = true, true
+		}
+	}
+	return out, nil
+}
+
+// closingTotal returns the total of the last holes of the scores.
+func closingTotal(scores []int, holes int) int {
+	if holes > len(scores) {
+		holes = len(scores)
+	}
+	var total int
+	for _, s := range scores[len(scores)-holes:] {
+		total += s
+	}
+	return total
+}
@@ -0,0 +1,40 @@
+package tournament
+
+import (
+	"context"
+
+	"github.com/C-Deck/ghin"
+	"github.com/pkg/errors"
+)
+
+// ScorePoster posts scores to GHIN. It is implemented by *ghin.Client.
+type ScorePoster interface {
+	SubmitScore(ctx context.Context, input ghin.SubmitScoreInput) (*ghin.Score, error)
+}
+
+// PostScores posts the recorded score of every entry that has not been posted yet as a tournament score. Posting
+// stops at the first score that fails. Entries posted before it keep their Posted score, so PostScores can be called
+// again once the problem is fixed without posting them twice.
+func (e *Event) PostScores(ctx context.Context, poster ScorePoster) error {
+	scoreType := ghin.ScoringType(ghin.ScoringTypeTournament)
+	for _, entry := range e.Entries {
+		if entry.Holes == nil || entry.Posted != nil {
+			continue
+		}
+		ghinNumber := entry.Golfer.GhinNumber
+		posted, err := poster.SubmitScore(ctx, ghin.SubmitScoreInput{
+			GolferID:    &ghinNumber,
+			Gender:      ghin.PlayerGender(entry.Golfer.Gender),
+			CourseID:    e.Course.CourseId,
+			TeeSetID:    e.Tee.TeeSetRatingId,
+			PlayedAt:    &e.PlayedAt,
+			HoleDetails: entry.Holes,
+			ScoreType:   &scoreType,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "problem posting score of golfer %s", ghinNumber)
+		}
+		entry.Posted = posted
+	}
+	return nil
+}
@@ -0,0 +1,225 @@
+// Package tournament runs individual stroke play events: golfers are registered by GHIN number, receive course and
+// playing handicaps for the tee set of the event, are split into flights by Handicap Index and ranked on gross and
+// net leaderboards. The scores of the event can then be posted to GHIN as tournament scores.
+package tournament
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/C-Deck/ghin"
+	"github.com/C-Deck/ghin/handicap"
+	"github.com/pkg/errors"
+)
+
+// DefaultAllowance is the percentage of their course handicap players receive in individual stroke play under the
+// Rules of Handicapping.
+const DefaultAllowance = 95
+
+// GolferLookup finds golfers by GHIN number. It is implemented by *ghin.Client.
+type GolferLookup interface {
+	GetGolfer(ctx context.Context, ghinNumber string) (*ghin.Golfer, error)
+}
+
+// Flight is a division of the field by Handicap Index.
+type Flight struct {
+	Name string
+	// MaxIndex is the highest Handicap Index of the flight, nil when the flight has no limit.
+	MaxIndex *float64
+}
+
+type EventInput struct {
+	Name   string
+	Course ghin.CourseDetails
+	// TeeSetID is the ID of the tee set of the course the event is played on.
+	TeeSetID int
+	PlayedAt time.Time
+
+	/* Allowance is the percentage of their course handicap players receive.
+	 * Default: DefaultAllowance
+	 */
+	Allowance *float64
+	/* Flights divide the field by Handicap Index, ordered from the lowest to the highest index. Golfers are
+	 * assigned to the first flight their index fits in.
+	 * Default: A single unnamed flight
+	 */
+	Flights []Flight
+}
+
+// Event is an individual stroke play event played on a tee set.
+type Event struct {
+	Name      string
+	Course    ghin.CourseDetails
+	Tee       ghin.TeeSetDetails
+	PlayedAt  time.Time
+	Allowance float64
+	Flights   []Flight
+	// Entries are the registered golfers in the order they registered.
+	Entries []*Entry
+
+	courseRating float64
+	slopeRating  int
+}
+
+// Entry is a golfer registered for an event.
+type Entry struct {
+	Golfer ghin.Golfer
+	// Index is the Handicap Index of the golfer when they registered, negative for plus handicaps.
+	Index           float64
+	CourseHandicap  int
+	PlayingHandicap int
+	// Flight is the name of the flight of the golfer.
+	Flight string
+	// Holes are the hole scores recorded for the golfer, nil until a score is recorded.
+	Holes []ghin.HoleScore
+	// Posted is the score posted to GHIN for the golfer, nil until the score is posted.
+	Posted *ghin.Score
+}
+
+// NewEvent creates an event on the tee set of the course.
+func NewEvent(input EventInput) (*Event, error) {
+	tee := input.Course.TeeSet(input.TeeSetID)
+	if tee == nil {
+		return nil, errors.Errorf("tee set %d is not on course %d", input.TeeSetID, input.Course.CourseId)
+	}
+	rating := tee.Rating(ghin.TeeSetRatingTypeTotal)
+	if rating == nil {
+		return nil, errors.Errorf("tee set %d has no %s rating", input.TeeSetID, ghin.TeeSetRatingTypeTotal)
+	}
+	for i := 1; i < len(input.Flights); i++ {
+		if input.Flights[i-1].MaxIndex == nil {
+			return nil, errors.Errorf("flight %q has no index limit but is not the last flight", input.Flights[i-1].Name)
+		}
+	}
+
+	out := &Event{
+		Name:         input.Name,
+		Course:       input.Course,
+		Tee:          *tee,
+		PlayedAt:     input.PlayedAt,
+		Allowance:    DefaultAllowance,
+		Flights:      input.Flights,
+		courseRating: rating.CourseRating,
+		slopeRating:  int(math.Round(rating.SlopeRating)),
+	}
+	if input.Allowance != nil {
+		out.Allowance = *input.Allowance
+	}
+	return out, nil
+}
+
+// Register looks up the golfer with the GHIN number and enters them in the event with handicaps calculated from their
+// current Handicap Index. The tee set of the event must be rated for the gender of the golfer.
+func (e *Event) Register(ctx context.Context, lookup GolferLookup, ghinNumber string) (*Entry, error) {
+	if e.Entry(ghinNumber) != nil {
+		return nil, errors.Errorf("golfer %s is already registered", ghinNumber)
+	}
+	golfer, err := lookup.GetGolfer(ctx, ghinNumber)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem looking up golfer %s", ghinNumber)
+	}
+	gender := ghin.PlayerGender(golfer.Gender)
+	if gender != ghin.PlayerGenderMale && gender != ghin.PlayerGenderFemale {
+		return nil, errors.Errorf("golfer %s has unknown gender %q", ghinNumber, golfer.Gender)
+	}
+	if string(e.Tee.Gender) != gender.LongString() {
+		return nil, errors.Errorf("golfer %s cannot play tee set %d, which is rated for %s golfers", ghinNumber,
+			e.Tee.TeeSetRatingId, e.Tee.Gender)
+	}
+	index, err := handicap.ParseIndex(golfer.Display)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem reading handicap index of golfer %s", ghinNumber)
+	}
+
+	entry := &Entry{
+		Golfer:         *golfer,
+		Index:          index,
+		CourseHandicap: handicap.CourseHandicap(index, e.courseRating, e.slopeRating, e.Tee.TotalPar),
+		PlayingHandicap: handicap.PlayingHandicap(index, e.courseRating, e.slopeRating, e.Tee.TotalPar,
+			e.Allowance),
+	}
+	flight, err := e.flightFor(index)
+	if err != nil {
+		return nil, errors.Wrapf(err, "problem assigning flight of golfer %s", ghinNumber)
+	}
+	entry.Flight = flight
+	e.Entries = append(e.Entries, entry)
+	return entry, nil
+}
+
+// Entry returns the entry of the golfer with the GHIN number, or nil if they are not registered.
+func (e *Event) Entry(ghinNumber string) *Entry {
+	for _, entry := range e.Entries {
+		if entry.Golfer.GhinNumber == ghinNumber {
+			return entry
+		}
+	}
+	return nil
+}
+
+// SplitFlights replaces the flights of the event with flights of equal size, ordered by Handicap Index. Earlier
+// flights take the extra golfers when the field does not split evenly.
+func (e *Event) SplitFlights(names []string) error {
+	if len(names) == 0 {
+		return errors.New("at least 1 flight is required")
+	}
+	entries := append([]*Entry(nil), e.Entries...)
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Index < entries[j].Index
+	})
+
+	e.Flights = make([]Flight, len(names))
+	size, extra := len(entries)/len(names), len(entries)%len(names)
+	next := 0
+	for i, name := range names {
+		count := size
+		if i < extra {
+			count++
+		}
+		e.Flights[i].Name = name
+		for _, entry := range entries[next : next+count] {
+			entry.Flight = name
+		}
+		next += count
+		if i < len(names)-1 && count > 0 {
+			limit := entries[next-1].Index
+			e.Flights[i].MaxIndex = &limit
+		}
+	}
+	return nil
+}
+
+// RecordScore records the hole scores of a registered golfer. The scores are validated as the 18 holes that
+// PostScores posts for the golfer, and every hole must be on the tee set of the event.
+func (e *Event) RecordScore(ghinNumber string, holes []ghin.HoleScore) error {
+	entry := e.Entry(ghinNumber)
+	if entry == nil {
+		return errors.Errorf("golfer %s is not registered", ghinNumber)
+	}
+	side := ghin.TeeSetSide18
+	if err := ghin.ValidateScoreUpdate(ghin.ScoreUpdate{TeeSetSide: &side, HoleDetails: holes}); err != nil {
+		return errors.Wrapf(err, "problem recording score of golfer %s", ghinNumber)
+	}
+	for _, h := range holes {
+		if e.Tee.Hole(h.HoleNumber) == nil {
+			return errors.Errorf("hole %d is not on tee set %d", h.HoleNumber, e.Tee.TeeSetRatingId)
+		}
+	}
+	entry.Holes = holes
+	return nil
+}
+
+// flightFor returns the name of the first flight the Handicap Index fits in.
+func (e *Event) flightFor(index float64) (string, error) {
+	if len(e.Flights) == 0 {
+		return "", nil
+	}
+	for _, f := range e.Flights {
+		if f.MaxIndex == nil || index <= *f.MaxIndex {
+			return f.Name, nil
+		}
+	}
+	return "", errors.Errorf("handicap index %.1f is above the limit of every flight", index)
+}
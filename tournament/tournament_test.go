@@ -0,0 +1,225 @@
+package tournament
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/C-Deck/ghin"
+	"github.com/C-Deck/ghin/formats"
+)
+
+// fakeLookup is a GolferLookup over golfers keyed by GHIN number.
+type fakeLookup map[string]ghin.Golfer
+
+func (f fakeLookup) GetGolfer(_ context.Context, ghinNumber string) (*ghin.Golfer, error) {
+	golfer, ok := f[ghinNumber]
+	if !ok {
+		return nil, fmt.Errorf("no golfer %s", ghinNumber)
+	}
+	return &golfer, nil
+}
+
+// testCourse returns a course with a men's tee set 1 of par 4 holes rated 72.0/113, whose allocation is their hole
+// number.
+func testCourse() ghin.CourseDetails {
+	tee := ghin.TeeSetDetails{
+		TeeSetRatingId: 1,
+		Gender:         ghin.TeeSetGenderMale,
+		TotalPar:       72,
+		Ratings: []ghin.TeeSetRating{
+			{TeeSetRatingType: string(ghin.TeeSetRatingTypeTotal), CourseRating: 72.0, SlopeRating: 113},
+		},
+	}
+	for i := 1; i <= 18; i++ {
+		tee.Holes = append(tee.Holes, ghin.HoleDetails{Number: i, Par: 4, Allocation: i})
+	}
+	return ghin.CourseDetails{CourseId: 1, TeeSets: []ghin.TeeSetDetails{tee}}
+}
+
+// testEvent returns an event on testCourse with golfers registered with the indexes, numbered from 1.
+func testEvent(t *testing.T, flights []Flight, indexes ...string) *Event {
+	t.Helper()
+	event, err := NewEvent(EventInput{
+		Course:   testCourse(),
+		TeeSetID: 1,
+		PlayedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Flights:  flights,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	lookup := fakeLookup{}
+	for i, index := range indexes {
+		number := fmt.Sprint(i + 1)
+		lookup[number] = ghin.Golfer{GhinNumber: number, Gender: string(ghin.PlayerGenderMale), Display: index}
+		if _, err := event.Register(context.Background(), lookup, number); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return event
+}
+
+// holes returns a score of 4 on every hole except the holes given as hole number and score pairs.
+func holes(changes ...int) []ghin.HoleScore {
+	out := make([]ghin.HoleScore, 18)
+	for i := range out {
+		out[i] = ghin.HoleScore{HoleNumber: i + 1, RawScore: 4}
+	}
+	for i := 0; i < len(changes); i += 2 {
+		out[changes[i]-1].RawScore = changes[i+1]
+	}
+	return out
+}
+
+func limit(index float64) *float64 {
+	return &index
+}
+
+func TestRegisterAssignsFlights(t *testing.T) {
+	flights := []Flight{{Name: "A", MaxIndex: limit(9.9)}, {Name: "B", MaxIndex: limit(19.9)}, {Name: "C"}}
+	event := testEvent(t, flights, "+1.2", "9.9", "10.0", "19.9", "30.5")
+
+	want := []string{"A", "A", "B", "B", "C"}
+	for i, entry := range event.Entries {
+		if entry.Flight != want[i] {
+			t.Errorf("golfer with index %.1f is in flight %q, want %q", entry.Index, entry.Flight, want[i])
+		}
+	}
+	if entry := event.Entries[0]; entry.Index != -1.2 || entry.CourseHandicap != -1 || entry.PlayingHandicap != -1 {
+		t.Errorf("plus handicap entry = %+v, want a course and playing handicap of -1", entry)
+	}
+}
+
+func TestRegisterRejects(t *testing.T) {
+	lookup := fakeLookup{
+		"1": {GhinNumber: "1", Gender: string(ghin.PlayerGenderMale), Display: "12.0"},
+		"2": {GhinNumber: "2", Gender: string(ghin.PlayerGenderFemale), Display: "12.0"},
+		"3": {GhinNumber: "3", Gender: string(ghin.PlayerGenderMale), Display: "30.0"},
+		"4": {GhinNumber: "4", Gender: "X", Display: "12.0"},
+	}
+	event, err := NewEvent(EventInput{
+		Course:   testCourse(),
+		TeeSetID: 1,
+		Flights:  []Flight{{Name: "A", MaxIndex: limit(9.9)}, {Name: "B", MaxIndex: limit(19.9)}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := event.Register(context.Background(), lookup, "1"); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		ghinNumber string
+	}{
+		{name: "already registered", ghinNumber: "1"},
+		{name: "tee set rated for the other gender", ghinNumber: "2"},
+		{name: "above every flight", ghinNumber: "3"},
+		{name: "unknown gender", ghinNumber: "4"},
+		{name: "unknown golfer", ghinNumber: "5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := event.Register(context.Background(), lookup, tt.ghinNumber); err == nil {
+				t.Errorf("expected an error registering golfer %s", tt.ghinNumber)
+			}
+		})
+	}
+	if len(event.Entries) != 1 {
+		t.Errorf("entries = %d, want only the first golfer registered", len(event.Entries))
+	}
+}
+
+func TestSplitFlights(t *testing.T) {
+	event := testEvent(t, nil, "20.0", "5.0", "15.0", "10.0", "25.0")
+	if err := event.SplitFlights([]string{"A", "B"}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"B", "A", "A", "A", "B"}
+	for i, entry := range event.Entries {
+		if entry.Flight != want[i] {
+			t.Errorf("golfer with index %.1f is in flight %q, want %q", entry.Index, entry.Flight, want[i])
+		}
+	}
+	if max := event.Flights[0].MaxIndex; max == nil || *max != 15.0 || event.Flights[1].MaxIndex != nil {
+		t.Errorf("flights = %+v, want A limited to 15.0 and B unlimited", event.Flights)
+	}
+}
+
+func TestLeaderboardCountback(t *testing.T) {
+	event := testEvent(t, nil, "0.0", "0.0", "0.0", "0.0", "0.0", "0.0", "0.0", "0.0")
+	scores := map[string][]ghin.HoleScore{
+		"1": holes(),
+		"2": holes(1, 5, 10, 3),  // better last 9
+		"3": holes(12, 5, 15, 3), // better last 6
+		"4": holes(14, 5, 17, 3), // better last 3
+		"5": holes(16, 5, 18, 3), // better last hole
+		"6": holes(),
+		"7": holes(18, 5),
+	}
+	for number, s := range scores {
+		if err := event.RecordScore(number, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	standings, err := event.Leaderboard(formats.ScoringGross, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []struct {
+		ghinNumber string
+		position   int
+		tied       bool
+	}{
+		{ghinNumber: "2", position: 1},
+		{ghinNumber: "3", position: 2},
+		{ghinNumber: "4", position: 3},
+		{ghinNumber: "5", position: 4},
+		{ghinNumber: "1", position: 5, tied: true},
+		{ghinNumber: "6", position: 5, tied: true},
+		{ghinNumber: "7", position: 7},
+	}
+	if len(standings) != len(want) {
+		t.Fatalf("leaderboard has %d standings, want %d without the golfer who did not record a score",
+			len(standings), len(want))
+	}
+	for i, w := range want {
+		got := standings[i]
+		if got.Entry.Golfer.GhinNumber != w.ghinNumber || got.Position != w.position || got.Tied != w.tied {
+			t.Errorf("standing %d = golfer %s at %d tied %t, want golfer %s at %d tied %t", i,
+				got.Entry.Golfer.GhinNumber, got.Position, got.Tied, w.ghinNumber, w.position, w.tied)
+		}
+	}
+	if standings[6].Gross != 73 || standings[6].ToPar != 1 {
+		t.Errorf("last standing = %+v, want 73, 1 over par", standings[6])
+	}
+}
+
+func TestLeaderboardNetByFlight(t *testing.T) {
+	flights := []Flight{{Name: "A", MaxIndex: limit(9.9)}, {Name: "B"}}
+	event := testEvent(t, flights, "0.0", "10.0", "12.0")
+	for number, s := range map[string][]ghin.HoleScore{"1": holes(), "2": holes(1, 6), "3": holes(1, 5, 2, 5)} {
+		if err := event.RecordScore(number, s); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	standings, err := event.Leaderboard(formats.ScoringNet, "B")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(standings) != 2 {
+		t.Fatalf("flight B has %d standings, want 2", len(standings))
+	}
+	// Playing handicaps of 10 and 11 at a 95% allowance leave nets of 64 and 63.
+	first, second := standings[0], standings[1]
+	if first.Entry.Golfer.GhinNumber != "3" || first.Net != 63 || first.ToPar != -9 ||
+		second.Entry.Golfer.GhinNumber != "2" || second.Net != 64 {
+		t.Errorf("standings = %+v, want golfer 3 on 63 ahead of golfer 2 on 64", standings)
+	}
+}
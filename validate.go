@@ -0,0 +1,183 @@
+package ghin
+
+import (
+	"fmt"
+	"time"
+)
+
+type sideHoleRange struct {
+	first, last int
+	holes       HolesPlayed
+}
+
+// sideHoles is the range of hole numbers that can be played on each side of a tee set.
+var sideHoles = map[TeeSetSide]sideHoleRange{
+	TeeSetSide18:    {first: 1, last: 18, holes: EighteenHolesPlayed},
+	TeeSetSideFront: {first: 1, last: 9, holes: NineHolesPlayed},
+	TeeSetSideBack:  {first: 10, last: 18, holes: NineHolesPlayed},
+}
+
+// fieldErrors collects the problems found while validating a request.
+type fieldErrors []FieldError
+
+func (f *fieldErrors) add(field, format string, args ...any) {
+	*f = append(*f, FieldError{Field: field, Msg: fmt.Sprintf(format, args...)})
+}
+
+func (f fieldErrors) err() error {
+	if len(f) > 0 {
+		return NewValidationError(f)
+	}
+	return nil
+}
+
+// ValidateSubmission checks a ScoreSubmission for problems GHIN would reject or that would record incorrect
+// statistics. The tee set is only checked when course is provided. A ValidationError listing every invalid field is
+// returned when the submission is not valid.
+func ValidateSubmission(submission ScoreSubmission, course *CourseDetails) error {
+	var fields fieldErrors
+
+	side := validateSide(&fields, submission.TeeSetSide, submission.NumberOfHoles)
+	if len(submission.HoleDetails) != int(submission.NumberOfHoles) {
+		fields.add("hole_details", "expected %d holes, got %d", submission.NumberOfHoles, len(submission.HoleDetails))
+	}
+	validateHoles(&fields, submission.HoleDetails, side, submission.TeeSetSide)
+
+	validateCourse(&fields, course, submission.CourseID, submission.TeeSetID, submission.Gender)
+	validatePlayedAt(&fields, submission.PlayedAt)
+
+	return fields.err()
+}
+
+// ValidateTotalSubmission checks a TotalScoreSubmission in the same way as ValidateSubmission, verifying that the
+// nine hole totals add up to the adjusted gross score when both are provided.
+func ValidateTotalSubmission(submission TotalScoreSubmission, course *CourseDetails) error {
+	var fields fieldErrors
+
+	validateSide(&fields, submission.TeeSetSide, submission.NumberOfHoles)
+	if submission.AdjustedGrossScore <= 0 {
+		fields.add("adjusted_gross_score", "score must be positive, got %d", submission.AdjustedGrossScore)
+	}
+	if submission.FrontNineScore != nil && *submission.FrontNineScore <= 0 {
+		fields.add("front9_adjusted", "score must be positive, got %d", *submission.FrontNineScore)
+	}
+	if submission.BackNineScore != nil && *submission.BackNineScore <= 0 {
+		fields.add("back9_adjusted", "score must be positive, got %d", *submission.BackNineScore)
+	}
+	if submission.FrontNineScore != nil && submission.BackNineScore != nil &&
+		*submission.FrontNineScore+*submission.BackNineScore != submission.AdjustedGrossScore {
+		fields.add("adjusted_gross_score", "nine hole scores %d and %d do not add up to %d",
+			*submission.FrontNineScore, *submission.BackNineScore, submission.AdjustedGrossScore)
+	}
+
+	validateCourse(&fields, course, submission.CourseID, submission.TeeSetID, submission.Gender)
+	validatePlayedAt(&fields, submission.PlayedAt)
+
+	return fields.err()
+}
+
+// ValidateScoreUpdate checks the fields of a ScoreUpdate that are provided using the same rules as
+// ValidateSubmission. HoleDetails replaces every hole of the score, so TeeSetSide is required with it to check the
+// number of holes and their numbers.
+func ValidateScoreUpdate(update ScoreUpdate) error {
+	var fields fieldErrors
+
+	var side *sideHoleRange
+	var teeSetSide TeeSetSide
+	if update.TeeSetSide != nil {
+		teeSetSide = *update.TeeSetSide
+		if s, ok := sideHoles[teeSetSide]; !ok {
+			fields.add("tee_set_side", "unknown tee set side %q", teeSetSide)
+		} else {
+			side = &s
+		}
+	}
+	if update.HoleDetails != nil {
+		if update.TeeSetSide == nil {
+			fields.add("tee_set_side", "tee set side is required when hole details are provided")
+		} else if side != nil && len(update.HoleDetails) != int(side.holes) {
+			fields.add("hole_details", "expected %d holes, got %d", side.holes, len(update.HoleDetails))
+		}
+	}
+	validateHoles(&fields, update.HoleDetails, side, teeSetSide)
+	if update.PlayedAt != nil {
+		validatePlayedAt(&fields, *update.PlayedAt)
+	}
+
+	return fields.err()
+}
+
+// validateHoles checks the scores and statistics of each hole. Hole numbers are only checked against the side when
+// its range is known.
+func validateHoles(fields *fieldErrors, holes []HoleScore, side *sideHoleRange, teeSetSide TeeSetSide) {
+	seen := make(map[int]bool, len(holes))
+	for i, hole := range holes {
+		field := func(name string) string {
+			return fmt.Sprintf("hole_details[%d].%s", i, name)
+		}
+
+		if seen[hole.HoleNumber] {
+			fields.add(field("hole_number"), "hole %d is scored more than once", hole.HoleNumber)
+		}
+		seen[hole.HoleNumber] = true
+		if side != nil && (hole.HoleNumber < side.first || hole.HoleNumber > side.last) {
+			fields.add(field("hole_number"), "hole %d is not played on tee set side %q", hole.HoleNumber, teeSetSide)
+		}
+
+		if hole.RawScore <= 0 {
+			fields.add(field("raw_score"), "score must be positive, got %d", hole.RawScore)
+		}
+		if hole.Putts != nil && (*hole.Putts < 0 || *hole.Putts > hole.RawScore) {
+			fields.add(field("putts"), "%d putts is not possible with a score of %d", *hole.Putts, hole.RawScore)
+		}
+		if hole.DriveAccuracy != nil && (hole.FairwayHit == nil || *hole.FairwayHit) {
+			fields.add(field("drive_accuracy"), "drive accuracy can only be provided when the fairway was missed")
+		}
+		if hole.ApproachShotAccuracy != nil && (hole.GreenInRegulation == nil || *hole.GreenInRegulation) {
+			fields.add(field("approach_shot_accuracy"),
+				"approach shot accuracy can only be provided when the green was missed in regulation")
+		}
+	}
+}
+
+// validateSide checks that the number of holes can be played on the side, returning the holes of the side or nil
+// if the side does not exist.
+func validateSide(fields *fieldErrors, teeSetSide TeeSetSide, numberOfHoles HolesPlayed) *sideHoleRange {
+	side, ok := sideHoles[teeSetSide]
+	if !ok {
+		fields.add("tee_set_side", "unknown tee set side %q", teeSetSide)
+		return nil
+	}
+	if side.holes != numberOfHoles {
+		fields.add("number_of_holes", "%d holes cannot be played on tee set side %q", numberOfHoles, teeSetSide)
+	}
+	return &side
+}
+
+// validateCourse checks the gender of the golfer and, when the course details are provided, the tee set played.
+func validateCourse(fields *fieldErrors, course *CourseDetails, courseID int, teeSetID int, gender PlayerGender) {
+	validGender := gender == PlayerGenderMale || gender == PlayerGenderFemale
+	if !validGender {
+		fields.add("gender", "gender must be %q or %q, got %q", PlayerGenderMale, PlayerGenderFemale, gender)
+	}
+	if course == nil {
+		return
+	}
+	if course.CourseId != courseID {
+		fields.add("course_id", "course details are for course %d", course.CourseId)
+	}
+	tee := course.TeeSet(teeSetID)
+	if tee == nil {
+		fields.add("tee_set_id", "tee set %d does not exist on course %d", teeSetID, course.CourseId)
+	} else if validGender && string(tee.Gender) != gender.LongString() {
+		fields.add("gender", "tee set %d is rated for %s golfers", teeSetID, tee.Gender)
+	}
+}
+
+func validatePlayedAt(fields *fieldErrors, playedAt string) {
+	if _, err := ParsePlayedAt(playedAt); err != nil {
+		fields.add("played_at", "date must be formatted as %s", playedAtDateFormat)
+	} else if playedAt > ToPlayedAtString(time.Now()) {
+		fields.add("played_at", "round cannot be played in the future")
+	}
+}
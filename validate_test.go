@@ -0,0 +1,177 @@
+package ghin
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// validSubmission returns an 18 hole submission on tee set 5 of course 1 that passes validation.
+func validSubmission() (ScoreSubmission, *CourseDetails) {
+	submission := ScoreSubmission{
+		Gender:        PlayerGenderMale,
+		CourseID:      1,
+		TeeSetID:      5,
+		TeeSetSide:    TeeSetSide18,
+		PlayedAt:      "2024-06-01",
+		NumberOfHoles: EighteenHolesPlayed,
+	}
+	for i := 1; i <= 18; i++ {
+		submission.HoleDetails = append(submission.HoleDetails, HoleScore{HoleNumber: i, RawScore: 4})
+	}
+	course := &CourseDetails{CourseId: 1, TeeSets: []TeeSetDetails{{TeeSetRatingId: 5, Gender: TeeSetGenderMale}}}
+	return submission, course
+}
+
+func TestValidateSubmission(t *testing.T) {
+	yes, no := true, false
+	putts, tooManyPutts := 2, 5
+	left := ShotAccuracyMissedLeft
+
+	tests := []struct {
+		name       string
+		modify     func(*ScoreSubmission, *CourseDetails)
+		wantFields []string
+	}{
+		{name: "valid", modify: func(*ScoreSubmission, *CourseDetails) {}},
+		{
+			name: "valid statistics",
+			modify: func(s *ScoreSubmission, _ *CourseDetails) {
+				s.HoleDetails[0].Putts = &putts
+				s.HoleDetails[0].FairwayHit = &no
+				s.HoleDetails[0].DriveAccuracy = &left
+				s.HoleDetails[0].GreenInRegulation = &no
+				s.HoleDetails[0].ApproachShotAccuracy = &left
+			},
+		},
+		{
+			name: "valid back nine",
+			modify: func(s *ScoreSubmission, _ *CourseDetails) {
+				s.TeeSetSide = TeeSetSideBack
+				s.NumberOfHoles = NineHolesPlayed
+				s.HoleDetails = s.HoleDetails[9:]
+			},
+		},
+		{
+			name:       "unknown side",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.TeeSetSide = "Middle" },
+			wantFields: []string{"tee_set_side"},
+		},
+		{
+			name:   "18 holes on one side",
+			modify: func(s *ScoreSubmission, _ *CourseDetails) { s.TeeSetSide = TeeSetSideFront },
+			wantFields: []string{"hole_details[9].hole_number", "hole_details[10].hole_number",
+				"hole_details[11].hole_number", "hole_details[12].hole_number", "hole_details[13].hole_number",
+				"hole_details[14].hole_number", "hole_details[15].hole_number", "hole_details[16].hole_number",
+				"hole_details[17].hole_number", "number_of_holes"},
+		},
+		{
+			name:       "missing hole",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.HoleDetails = s.HoleDetails[1:] },
+			wantFields: []string{"hole_details"},
+		},
+		{
+			name:       "duplicate hole",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.HoleDetails[1].HoleNumber = 1 },
+			wantFields: []string{"hole_details[1].hole_number"},
+		},
+		{
+			name:       "score not positive",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.HoleDetails[2].RawScore = 0 },
+			wantFields: []string{"hole_details[2].raw_score"},
+		},
+		{
+			name:       "more putts than strokes",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.HoleDetails[3].Putts = &tooManyPutts },
+			wantFields: []string{"hole_details[3].putts"},
+		},
+		{
+			name: "drive accuracy with the fairway hit",
+			modify: func(s *ScoreSubmission, _ *CourseDetails) {
+				s.HoleDetails[4].FairwayHit = &yes
+				s.HoleDetails[4].DriveAccuracy = &left
+			},
+			wantFields: []string{"hole_details[4].drive_accuracy"},
+		},
+		{
+			name:       "approach accuracy without the green in regulation",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.HoleDetails[5].ApproachShotAccuracy = &left },
+			wantFields: []string{"hole_details[5].approach_shot_accuracy"},
+		},
+		{
+			name:       "unknown gender",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.Gender = "X" },
+			wantFields: []string{"gender"},
+		},
+		{
+			name:       "tee set rated for the other gender",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.Gender = PlayerGenderFemale },
+			wantFields: []string{"gender"},
+		},
+		{
+			name:       "other course",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.CourseID = 2 },
+			wantFields: []string{"course_id"},
+		},
+		{
+			name:       "unknown tee set",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.TeeSetID = 6 },
+			wantFields: []string{"tee_set_id"},
+		},
+		{
+			name:       "invalid date",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.PlayedAt = "06/01/2024" },
+			wantFields: []string{"played_at"},
+		},
+		{
+			name:       "future date",
+			modify:     func(s *ScoreSubmission, _ *CourseDetails) { s.PlayedAt = "2999-01-01" },
+			wantFields: []string{"played_at"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			submission, course := validSubmission()
+			tt.modify(&submission, course)
+			err := ValidateSubmission(submission, course)
+			if got := validationFields(t, err); !reflect.DeepEqual(got, sortedFields(tt.wantFields)) {
+				t.Errorf("ValidateSubmission invalid fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestValidateSubmissionWithoutCourse(t *testing.T) {
+	submission, _ := validSubmission()
+	submission.TeeSetID = 6
+	if err := ValidateSubmission(submission, nil); err != nil {
+		t.Errorf("ValidateSubmission without course details = %v, want the tee set to be left unchecked", err)
+	}
+}
+
+// validationFields returns the sorted fields of a ValidationError, or nil when err is nil.
+func validationFields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("error = %v, want a ValidationError", err)
+	}
+	fields := make([]string, len(validationErr.Fields))
+	for i, f := range validationErr.Fields {
+		fields[i] = f.Field
+	}
+	return sortedFields(fields)
+}
+
+func sortedFields(fields []string) []string {
+	if len(fields) == 0 {
+		return nil
+	}
+	out := append([]string(nil), fields...)
+	sort.Strings(out)
+	return out
+}